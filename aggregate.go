@@ -0,0 +1,142 @@
+package netmath
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+)
+
+// Inclusive span of addresses within a single address family
+type span struct {
+	from uint128
+	to   uint128
+}
+
+func subnetSpan(s Subnet) (span, error) {
+	if !s.IsValid() {
+		return span{}, ErrInvalidPrefix
+	}
+
+	from, to, err := getHostBounds(s.Addr(), s.Bits(), false)
+	if err != nil {
+		return span{}, err
+	}
+	return span{from: from, to: to}, nil
+}
+
+// Sort spans and merge the ones that overlap or touch
+func mergeSpans(spans []span) []span {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	sorted := slices.Clone(spans)
+	slices.SortFunc(sorted, func(a, b span) int {
+		return a.from.cmp(b.from)
+	})
+
+	merged := []span{sorted[0]}
+	for _, sp := range sorted[1:] {
+		cur := &merged[len(merged)-1]
+		if sp.from.cmp(cur.to) <= 0 || sp.from == cur.to.addOne() {
+			if sp.to.cmp(cur.to) > 0 {
+				cur.to = sp.to
+			}
+			continue
+		}
+		merged = append(merged, sp)
+	}
+
+	return merged
+}
+
+// Decompose a span into the minimal list of prefixes that covers it exactly
+func spanPrefixes(sp span, is4 bool) []Subnet {
+	width := 32
+	if !is4 {
+		width = 128
+	}
+
+	var subnets []Subnet
+	from := sp.from
+	for {
+		// Grow the block while it stays aligned and inside the span
+		hostBits := min(from.trailingZeros(), width)
+		for hostBits > 0 && from.add(hostMask(width-hostBits, width)).cmp(sp.to) > 0 {
+			hostBits--
+		}
+
+		last := from.add(hostMask(width-hostBits, width))
+		subnets = append(subnets, NewSubnet(netip.PrefixFrom(from.addr(is4), width-hostBits)))
+
+		if last == sp.to {
+			return subnets
+		}
+		from = last.addOne()
+	}
+}
+
+// Split subnets into IPv4 and IPv6 spans
+func familySpans(subnets []Subnet) ([]span, []span, error) {
+	var v4, v6 []span
+	for _, s := range subnets {
+		sp, err := subnetSpan(s)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if s.Addr().Is4() {
+			v4 = append(v4, sp)
+		} else {
+			v6 = append(v6, sp)
+		}
+	}
+	return v4, v6, nil
+}
+
+// Get the smallest single subnet that covers every given subnet ex. [10.0.1.0/24 10.0.2.0/24] -> 10.0.0.0/22
+func Supernet(subnets []Subnet) (Subnet, error) {
+	if len(subnets) == 0 {
+		return Subnet{}, fmt.Errorf("no subnets")
+	}
+
+	v4, v6, err := familySpans(subnets)
+	if err != nil {
+		return Subnet{}, err
+	}
+	if len(v4) > 0 && len(v6) > 0 {
+		return Subnet{}, fmt.Errorf("mixed address families")
+	}
+
+	spans, is4, width := v4, true, 32
+	if len(v6) > 0 {
+		spans, is4, width = v6, false, 128
+	}
+
+	lowest := slices.MinFunc(spans, func(a, b span) int { return a.from.cmp(b.from) })
+	highest := slices.MaxFunc(spans, func(a, b span) int { return a.to.cmp(b.to) })
+
+	// The supernet keeps the leading bits that the lowest and highest addresses share
+	bits := width - lowest.from.xor(highest.to).bitLen()
+
+	return NewSubnet(netip.PrefixFrom(lowest.from.addr(is4), bits).Masked()), nil
+}
+
+// Get the minimal list of subnets that exactly covers the union of the given subnets, IPv4 first
+// ex. [10.0.0.0/24 10.0.1.0/24 10.0.2.0/24] -> [10.0.0.0/23 10.0.2.0/24]
+func Aggregate(subnets []Subnet) ([]Subnet, error) {
+	v4, v6, err := familySpans(subnets)
+	if err != nil {
+		return nil, err
+	}
+
+	var aggregated []Subnet
+	for _, sp := range mergeSpans(v4) {
+		aggregated = append(aggregated, spanPrefixes(sp, true)...)
+	}
+	for _, sp := range mergeSpans(v6) {
+		aggregated = append(aggregated, spanPrefixes(sp, false)...)
+	}
+
+	return aggregated, nil
+}
@@ -0,0 +1,122 @@
+package netmath
+
+import (
+	"net/netip"
+	"slices"
+	"testing"
+)
+
+func parseSubnets(t *testing.T, list []string) []Subnet {
+	t.Helper()
+
+	var subnets []Subnet
+	for _, str := range list {
+		s, err := ParseCIDR(str)
+		if err != nil {
+			t.Fatal("Error parsing", str, "Error:", err)
+		}
+		subnets = append(subnets, s)
+	}
+	return subnets
+}
+
+func subnetStrings(subnets []Subnet) []string {
+	var strs []string
+	for _, s := range subnets {
+		strs = append(strs, s.String())
+	}
+	return strs
+}
+
+func TestSupernet(t *testing.T) {
+	supernetTests := []struct {
+		snets []string
+		want  string
+	}{
+		{snets: []string{"10.0.1.0/24", "10.0.2.0/24"}, want: "10.0.0.0/22"},
+		{snets: []string{"10.0.0.0/24", "10.0.1.0/24"}, want: "10.0.0.0/23"},
+		{snets: []string{"192.168.1.77/24"}, want: "192.168.1.0/24"},
+		{snets: []string{"10.0.0.0/8", "10.20.30.0/24"}, want: "10.0.0.0/8"},
+		{snets: []string{"0.0.0.0/1", "128.0.0.0/1"}, want: "0.0.0.0/0"},
+		{snets: []string{"2001:db8::/48", "2001:db8:ff::/48"}, want: "2001:db8::/40"},
+		{snets: []string{"::/128", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff/128"}, want: "::/0"},
+	}
+
+	for _, test := range supernetTests {
+		s, err := Supernet(parseSubnets(t, test.snets))
+		if err != nil || s.String() != test.want {
+			t.Error("Error getting Supernet() for", test.snets, "Expected:", test.want, "Got:", s.String(), "With Error:", err)
+		}
+	}
+
+	invalidTests := [][]string{
+		{},
+		{"10.0.0.0/8", "2001:db8::/32"},
+	}
+
+	for _, test := range invalidTests {
+		if _, err := Supernet(parseSubnets(t, test)); err == nil {
+			t.Error("Error getting Supernet() for", test, "Expected an error")
+		}
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	aggregateTests := []struct {
+		snets []string
+		want  []string
+	}{
+		{snets: []string{"10.0.0.0/24", "10.0.1.0/24", "10.0.2.0/24"}, want: []string{"10.0.0.0/23", "10.0.2.0/24"}},
+		{snets: []string{"10.0.3.0/24", "10.0.2.0/24", "10.0.1.0/24", "10.0.0.0/24"}, want: []string{"10.0.0.0/22"}},
+		{snets: []string{"10.0.0.0/8", "10.1.0.0/16", "10.1.1.1/32"}, want: []string{"10.0.0.0/8"}},
+		{snets: []string{"10.0.1.0/24", "10.0.2.0/24"}, want: []string{"10.0.1.0/24", "10.0.2.0/24"}},
+		{snets: []string{"192.168.0.0/25", "192.168.0.128/26", "192.168.0.192/26"}, want: []string{"192.168.0.0/24"}},
+		{snets: []string{"0.0.0.0/1", "128.0.0.0/1"}, want: []string{"0.0.0.0/0"}},
+		{snets: []string{"2001:db8:1::/48", "10.0.0.0/25", "2001:db8::/48", "10.0.0.128/25"}, want: []string{"10.0.0.0/24", "2001:db8::/47"}},
+		{snets: []string{"::/1", "8000::/1"}, want: []string{"::/0"}},
+		{snets: []string{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe/128", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff/128"}, want: []string{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe/127"}},
+		{snets: nil, want: nil},
+	}
+
+	for _, test := range aggregateTests {
+		list, err := Aggregate(parseSubnets(t, test.snets))
+		if got := subnetStrings(list); err != nil || !slices.Equal(got, test.want) {
+			t.Error("Error getting Aggregate() for", test.snets, "Expected:", test.want, "Got:", got, "With Error:", err)
+		}
+	}
+
+	if _, err := Aggregate([]Subnet{{}}); err == nil {
+		t.Error("Error getting Aggregate() for an invalid subnet, Expected an error")
+	}
+}
+
+func TestSpanPrefixes(t *testing.T) {
+	spanTests := []struct {
+		from string
+		to   string
+		want []string
+	}{
+		{from: "10.0.0.5", to: "10.0.0.77", want: []string{"10.0.0.5/32", "10.0.0.6/31", "10.0.0.8/29", "10.0.0.16/28", "10.0.0.32/27", "10.0.0.64/29", "10.0.0.72/30", "10.0.0.76/31"}},
+		{from: "0.0.0.0", to: "255.255.255.255", want: []string{"0.0.0.0/0"}},
+		{from: "255.255.255.255", to: "255.255.255.255", want: []string{"255.255.255.255/32"}},
+		{from: "::1", to: "::3", want: []string{"::1/128", "::2/127"}},
+	}
+
+	for _, test := range spanTests {
+		from, to := mustParseAddr(t, test.from), mustParseAddr(t, test.to)
+		sp := span{from: addrToUint128(from), to: addrToUint128(to)}
+		if got := subnetStrings(spanPrefixes(sp, from.Is4())); !slices.Equal(got, test.want) {
+			t.Error("Error getting spanPrefixes() for", test.from, "-", test.to, "Expected:", test.want, "Got:", got)
+		}
+	}
+}
+
+func mustParseAddr(t *testing.T, s string) netip.Addr {
+	t.Helper()
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		t.Fatal("Error parsing", s, "Error:", err)
+	}
+	return addr
+}
@@ -0,0 +1,249 @@
+package netmath
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/netip"
+	"slices"
+	"sync"
+)
+
+// Subnet or single address handed out by an Allocator. Addresses are stored as /32 or /128 subnets.
+type Allocation struct {
+	Subnet   Subnet            `json:"subnet"`
+	Owner    string            `json:"owner,omitempty"`
+	Tags     map[string]string `json:"tags,omitempty"`
+	Reserved bool              `json:"reserved,omitempty"`
+}
+
+// IP address manager that hands out free prefixes and addresses from a list of pools.
+// It is safe for concurrent use and its state can be saved and restored with encoding/json.
+type Allocator struct {
+	mu     sync.Mutex
+	pools  []Subnet
+	allocs Table[Allocation]
+}
+
+// Serialized state of an Allocator
+type allocatorState struct {
+	Pools       []Subnet     `json:"pools"`
+	Allocations []Allocation `json:"allocations"`
+}
+
+// Create a new Allocator over non-overlapping pools, searched in the given order
+func NewAllocator(pools ...Subnet) (*Allocator, error) {
+	a := &Allocator{}
+	if err := a.setPools(pools); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (a *Allocator) setPools(pools []Subnet) error {
+	var canonical []Subnet
+	for _, p := range pools {
+		if !p.IsValid() {
+			return ErrInvalidPrefix
+		}
+
+		p = NewSubnet(p.Masked())
+		for _, o := range canonical {
+			if p.Overlaps(o.Prefix) {
+				return fmt.Errorf("%w: pool %s overlaps pool %s", ErrOverlap, p, o)
+			}
+		}
+		canonical = append(canonical, p)
+	}
+
+	a.pools = canonical
+	return nil
+}
+
+// Get the pools managed by the allocator
+func (a *Allocator) Pools() []Subnet {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return slices.Clone(a.pools)
+}
+
+// Get every allocation and reservation in address order
+func (a *Allocator) Allocations() []Allocation {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var allocs []Allocation
+	for _, alloc := range a.allocs.All() {
+		alloc.Tags = maps.Clone(alloc.Tags)
+		allocs = append(allocs, alloc)
+	}
+	return allocs
+}
+
+// Find the allocation that holds the address
+func (a *Allocator) Lookup(addr netip.Addr) (Allocation, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	_, alloc, ok := a.allocs.Lookup(addr)
+	alloc.Tags = maps.Clone(alloc.Tags)
+	return alloc, ok
+}
+
+// Allocate the lowest free prefix of the given length from the first pool with room for it
+func (a *Allocator) AllocatePrefix(bits int, owner string, tags map[string]string) (Subnet, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	used := a.used()
+	for _, pool := range a.pools {
+		if bits < pool.Bits() || bits > pool.Addr().BitLen() {
+			continue
+		}
+
+		poolSet, _ := NewIPSet(pool)
+		// Every aligned block of the requested length lies inside a single free prefix that is at least as large
+		for _, free := range poolSet.Difference(used).Prefixes() {
+			if free.Bits() <= bits {
+				s := NewSubnet(netip.PrefixFrom(free.Addr(), bits))
+				a.insert(Allocation{Subnet: s, Owner: owner, Tags: tags})
+				return s, nil
+			}
+		}
+	}
+
+	return Subnet{}, fmt.Errorf("%w for a /%d prefix", ErrNoSpace, bits)
+}
+
+// Allocate the lowest free usable address from the first pool with room for it
+func (a *Allocator) AllocateAddr(owner string, tags map[string]string) (netip.Addr, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	used := a.used()
+	for _, pool := range a.pools {
+		usable, err := pool.UsableRange()
+		if err != nil {
+			continue
+		}
+
+		usableSet, _ := IPSetFromRanges(usable)
+		free := usableSet.Difference(used).Prefixes()
+		if len(free) > 0 {
+			addr := free[0].Addr()
+			a.insert(Allocation{Subnet: NewSubnet(netip.PrefixFrom(addr, addr.BitLen())), Owner: owner, Tags: tags})
+			return addr, nil
+		}
+	}
+
+	return netip.Addr{}, fmt.Errorf("%w for an address", ErrNoSpace)
+}
+
+// Reserve a specific subnet inside one of the pools so it is never handed out
+func (a *Allocator) Reserve(s Subnet, owner string, tags map[string]string) error {
+	if !s.IsValid() {
+		return ErrInvalidPrefix
+	}
+	s = NewSubnet(s.Masked())
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := a.checkFree(s); err != nil {
+		return err
+	}
+
+	a.insert(Allocation{Subnet: s, Owner: owner, Tags: tags, Reserved: true})
+	return nil
+}
+
+// Release an allocation or reservation so its space can be handed out again
+func (a *Allocator) Release(s Subnet) error {
+	if !s.IsValid() {
+		return ErrInvalidPrefix
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.allocs.Delete(NewSubnet(s.Masked())) {
+		return fmt.Errorf("%s is %w", s, ErrNotAllocated)
+	}
+	return nil
+}
+
+// Save the pools and allocations as JSON
+func (a *Allocator) MarshalJSON() ([]byte, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	state := allocatorState{Pools: a.pools, Allocations: []Allocation{}}
+	for _, alloc := range a.allocs.All() {
+		state.Allocations = append(state.Allocations, alloc)
+	}
+	return json.Marshal(state)
+}
+
+// Restore the pools and allocations saved by MarshalJSON, replacing the current state
+func (a *Allocator) UnmarshalJSON(data []byte) error {
+	var state allocatorState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+
+	restored := &Allocator{}
+	if err := restored.setPools(state.Pools); err != nil {
+		return err
+	}
+	for _, alloc := range state.Allocations {
+		if !alloc.Subnet.IsValid() {
+			return ErrInvalidPrefix
+		}
+		alloc.Subnet = NewSubnet(alloc.Subnet.Masked())
+		if err := restored.checkFree(alloc.Subnet); err != nil {
+			return err
+		}
+		restored.insert(alloc)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.pools, a.allocs = restored.pools, restored.allocs
+	return nil
+}
+
+// Check that s is inside a pool and does not overlap any allocation
+func (a *Allocator) checkFree(s Subnet) error {
+	inPool := slices.ContainsFunc(a.pools, func(p Subnet) bool {
+		return p.Addr().Is4() == s.Addr().Is4() && p.Bits() <= s.Bits() && p.Contains(s.Addr())
+	})
+	if !inPool {
+		return fmt.Errorf("%s is %w", s, ErrOutsidePools)
+	}
+
+	for existing := range a.allocs.Covering(s) {
+		return fmt.Errorf("%w: %s overlaps allocation %s", ErrOverlap, s, existing)
+	}
+	for existing := range a.allocs.Covered(s) {
+		return fmt.Errorf("%w: %s overlaps allocation %s", ErrOverlap, s, existing)
+	}
+	return nil
+}
+
+func (a *Allocator) insert(alloc Allocation) {
+	alloc.Tags = maps.Clone(alloc.Tags)
+	a.allocs.Insert(alloc.Subnet, alloc)
+}
+
+// Set of every allocated address
+func (a *Allocator) used() IPSet {
+	var subnets []Subnet
+	for s := range a.allocs.All() {
+		subnets = append(subnets, s)
+	}
+
+	used, _ := NewIPSet(subnets...)
+	return used
+}
@@ -0,0 +1,137 @@
+package netmath
+
+import (
+	"encoding/json"
+	"errors"
+	"net/netip"
+	"testing"
+)
+
+func TestAllocatePrefix(t *testing.T) {
+	pools := parseSubnets(t, []string{"10.0.0.0/22", "2001:db8::/48"})
+	a, err := NewAllocator(pools...)
+	if err != nil {
+		t.Fatal("Error creating allocator, Error:", err)
+	}
+
+	reserved, _ := ParseCIDR("10.0.0.0/25")
+	if err := a.Reserve(reserved, "network team", nil); err != nil {
+		t.Fatal("Error reserving", reserved, "Error:", err)
+	}
+
+	allocTests := []struct {
+		bits int
+		want string
+		err  error
+	}{
+		{bits: 24, want: "10.0.1.0/24"},
+		{bits: 26, want: "10.0.0.128/26"},
+		{bits: 23, want: "10.0.2.0/23"},
+		{bits: 24, err: ErrNoSpace}, // IPv4 pool is full at this size and the IPv6 pool is too small
+		{bits: 64, want: "2001:db8::/64"},
+		{bits: 26, want: "10.0.0.192/26"},
+		{bits: 30, err: ErrNoSpace},
+	}
+
+	for _, test := range allocTests {
+		s, err := a.AllocatePrefix(test.bits, "tenant", map[string]string{"site": "hq"})
+		if err != nil || test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Error("Error allocating /", test.bits, "Expected:", test.err, "With Error:", err)
+			}
+		} else if s.String() != test.want {
+			t.Error("Error allocating /", test.bits, "Expected:", test.want, "Got:", s)
+		}
+	}
+
+	alloc, ok := a.Lookup(netip.MustParseAddr("10.0.1.77"))
+	if !ok || alloc.Subnet.String() != "10.0.1.0/24" || alloc.Owner != "tenant" || alloc.Tags["site"] != "hq" || alloc.Reserved {
+		t.Error("Error getting .Lookup() for 10.0.1.77 Got:", alloc, ok)
+	}
+
+	released, _ := ParseCIDR("10.0.1.0/24")
+	if err := a.Release(released); err != nil {
+		t.Error("Error releasing", released, "Error:", err)
+	}
+	if err := a.Release(released); !errors.Is(err, ErrNotAllocated) {
+		t.Error("Error releasing", released, "twice, Expected:", ErrNotAllocated, "Got:", err)
+	}
+	if s, err := a.AllocatePrefix(25, "", nil); err != nil || s.String() != "10.0.1.0/25" {
+		t.Error("Error allocating /25 after release, Expected: 10.0.1.0/25 Got:", s, "With Error:", err)
+	}
+}
+
+func TestAllocateAddr(t *testing.T) {
+	pools := parseSubnets(t, []string{"192.168.0.0/30", "2001:db8::/126"})
+	a, _ := NewAllocator(pools...)
+
+	want := []string{"192.168.0.1", "192.168.0.2", "2001:db8::1", "2001:db8::2", "2001:db8::3"}
+	for _, w := range want {
+		addr, err := a.AllocateAddr("host", nil)
+		if err != nil || addr.String() != w {
+			t.Error("Error allocating an address, Expected:", w, "Got:", addr, "With Error:", err)
+		}
+	}
+
+	if addr, err := a.AllocateAddr("host", nil); !errors.Is(err, ErrNoSpace) {
+		t.Error("Error allocating from full pools, Expected:", ErrNoSpace, "Got:", addr, err)
+	}
+}
+
+func TestAllocatorReserve(t *testing.T) {
+	a, _ := NewAllocator(parseSubnets(t, []string{"10.0.0.0/24"})...)
+
+	reserveTests := []struct {
+		snet string
+		err  error
+	}{
+		{snet: "10.0.0.0/26"},
+		{snet: "10.0.0.0/27", err: ErrOverlap},
+		{snet: "10.0.0.0/25", err: ErrOverlap},
+		{snet: "10.0.0.64/26"},
+		{snet: "10.0.1.0/26", err: ErrOutsidePools},
+		{snet: "10.0.0.0/23", err: ErrOutsidePools},
+		{snet: "::/64", err: ErrOutsidePools},
+	}
+
+	for _, test := range reserveTests {
+		s, _ := ParseCIDR(test.snet)
+		if err := a.Reserve(s, "", nil); !errors.Is(err, test.err) {
+			t.Error("Error reserving", test.snet, "Expected:", test.err, "Got:", err)
+		}
+	}
+
+	if _, err := NewAllocator(parseSubnets(t, []string{"10.0.0.0/8", "10.1.0.0/16"})...); !errors.Is(err, ErrOverlap) {
+		t.Error("Error creating allocator with overlapping pools, Expected:", ErrOverlap, "Got:", err)
+	}
+}
+
+func TestAllocatorJSON(t *testing.T) {
+	a, _ := NewAllocator(parseSubnets(t, []string{"10.0.0.0/24"})...)
+	a.AllocatePrefix(26, "web", map[string]string{"env": "prod"})
+	reserved, _ := ParseCIDR("10.0.0.128/25")
+	a.Reserve(reserved, "ops", nil)
+
+	data, err := json.Marshal(a)
+	if err != nil {
+		t.Fatal("Error marshaling allocator, Error:", err)
+	}
+
+	want := `{"pools":["10.0.0.0/24"],"allocations":[{"subnet":"10.0.0.0/26","owner":"web","tags":{"env":"prod"}},{"subnet":"10.0.0.128/25","owner":"ops","reserved":true}]}`
+	if string(data) != want {
+		t.Error("Error marshaling allocator, Expected:", want, "Got:", string(data))
+	}
+
+	var restored Allocator
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatal("Error restoring allocator, Error:", err)
+	}
+	if s, err := restored.AllocatePrefix(26, "", nil); err != nil || s.String() != "10.0.0.64/26" {
+		t.Error("Error allocating from restored allocator, Expected: 10.0.0.64/26 Got:", s, "With Error:", err)
+	}
+
+	invalid := `{"pools":["10.0.0.0/24"],"allocations":[{"subnet":"10.0.0.0/26"},{"subnet":"10.0.0.0/25"}]}`
+	if err := json.Unmarshal([]byte(invalid), &restored); err == nil {
+		t.Error("Error restoring overlapping allocations, Expected an error")
+	}
+}
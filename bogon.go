@@ -0,0 +1,181 @@
+package netmath
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Output format of RenderPrefixList
+type RouterFormat int
+
+const (
+	FormatPlain           RouterFormat = iota // One prefix per line
+	FormatCiscoPrefixList                     // ip prefix-list / ipv6 prefix-list entries
+	FormatJunos                               // policy-options route-filter-list with orlonger
+	FormatBIRD                                // BIRD 2 prefix set constants, split by family
+)
+
+var routerFormatNames = map[RouterFormat]string{
+	FormatPlain:           "plain",
+	FormatCiscoPrefixList: "cisco",
+	FormatJunos:           "junos",
+	FormatBIRD:            "bird",
+}
+
+func (f RouterFormat) String() string {
+	if name, ok := routerFormatNames[f]; ok {
+		return name
+	}
+	return "RouterFormat(" + strconv.Itoa(int(f)) + ")"
+}
+
+// Prefixes that should never appear as a source, destination or route on the public internet
+var bogonPrefixes = []string{
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.0.0.0/24",
+	"192.0.2.0/24",
+	"192.88.99.0/24",
+	"192.168.0.0/16",
+	"198.18.0.0/15",
+	"198.51.100.0/24",
+	"203.0.113.0/24",
+	"224.0.0.0/4",
+	"240.0.0.0/4",
+
+	"::/8",
+	"100::/64",
+	"2001:2::/48",
+	"2001:10::/28",
+	"2001:db8::/32",
+	"2002::/16",
+	"3ffe::/16",
+	"3fff::/20",
+	"5f00::/16",
+	"fc00::/7",
+	"fe80::/10",
+	"fec0::/10",
+	"ff00::/8",
+}
+
+var bogonTable = newBogonTable()
+
+func newBogonTable() *Table[struct{}] {
+	t := &Table[struct{}]{}
+	for _, p := range bogonPrefixes {
+		t.Insert(mustParseCIDR(p), struct{}{})
+	}
+	return t
+}
+
+// Get the IPv4 and IPv6 bogon/martian prefixes, IPv4 first, in address order
+func Bogons() []Subnet {
+	var subnets []Subnet
+	for s := range bogonTable.All() {
+		subnets = append(subnets, s)
+	}
+	return subnets
+}
+
+// Check if the subnet overlaps any bogon prefix ex. 192.0.2.0/25 -> true
+func (s Subnet) IsBogon() bool {
+	return len(s.OverlappingBogons()) > 0
+}
+
+// List the bogon prefixes that overlap the subnet ex. 192.0.0.0/22 -> [192.0.0.0/24 192.0.2.0/24]
+func (s Subnet) OverlappingBogons() []Subnet {
+	var subnets []Subnet
+	for b := range bogonTable.Covering(s) {
+		if b.Bits() < s.Bits() {
+			subnets = append(subnets, b)
+		}
+	}
+	for b := range bogonTable.Covered(s) {
+		subnets = append(subnets, b)
+	}
+	return subnets
+}
+
+// Render the subnets as a prefix list named name that matches each subnet and every more specific prefix.
+// Junos output is a route-filter-list with orlonger since a Junos prefix-list only matches exact prefixes.
+// BIRD output defines <name>_v4 and <name>_v6 since a prefix set holds a single family.
+// ex. RenderPrefixList("BOGONS", Bogons(), FormatCiscoPrefixList)
+func RenderPrefixList(name string, subnets []Subnet, format RouterFormat) (string, error) {
+	if format != FormatPlain && (name == "" || strings.ContainsFunc(name, isPrefixListSpace)) {
+		return "", fmt.Errorf("invalid prefix list name %q", name)
+	}
+
+	var v4, v6 []Subnet
+	for _, s := range subnets {
+		if !s.IsValid() {
+			return "", ErrInvalidPrefix
+		}
+		s = NewSubnet(s.Masked())
+		if s.Addr().Is4() {
+			v4 = append(v4, s)
+		} else {
+			v6 = append(v6, s)
+		}
+	}
+
+	var b strings.Builder
+	switch format {
+	case FormatPlain:
+		for _, s := range subnets {
+			fmt.Fprintln(&b, s.Masked())
+		}
+	case FormatCiscoPrefixList:
+		for i, s := range v4 {
+			fmt.Fprintf(&b, "ip prefix-list %s seq %d permit %s%s\n", name, (i+1)*5, s, ciscoLe(s))
+		}
+		for i, s := range v6 {
+			fmt.Fprintf(&b, "ipv6 prefix-list %s seq %d permit %s%s\n", name, (i+1)*5, s, ciscoLe(s))
+		}
+	case FormatJunos:
+		fmt.Fprintf(&b, "policy-options {\n    route-filter-list %s {\n", name)
+		for _, s := range subnets {
+			fmt.Fprintf(&b, "        %s orlonger;\n", s.Masked())
+		}
+		fmt.Fprintf(&b, "    }\n}\n")
+	case FormatBIRD:
+		writeBIRDSet(&b, name+"_v4", v4)
+		writeBIRDSet(&b, name+"_v6", v6)
+	default:
+		return "", fmt.Errorf("unknown router format %v", format)
+	}
+
+	return b.String(), nil
+}
+
+func isPrefixListSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == ';' || r == '{' || r == '}'
+}
+
+// Match every more specific prefix, host routes need no le
+func ciscoLe(s Subnet) string {
+	if s.Bits() == s.Addr().BitLen() {
+		return ""
+	}
+	return " le " + strconv.Itoa(s.Addr().BitLen())
+}
+
+func writeBIRDSet(b *strings.Builder, name string, subnets []Subnet) {
+	if len(subnets) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "define %s = [\n", name)
+	for i, s := range subnets {
+		sep := ","
+		if i == len(subnets)-1 {
+			sep = ""
+		}
+		fmt.Fprintf(b, "    %s+%s\n", s, sep)
+	}
+	fmt.Fprintf(b, "];\n")
+}
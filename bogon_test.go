@@ -0,0 +1,83 @@
+package netmath
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBogons(t *testing.T) {
+	bogons := subnetStrings(Bogons())
+	if len(bogons) != len(bogonPrefixes) {
+		t.Error("Error getting Bogons()", "Expected:", len(bogonPrefixes), "Got:", len(bogons))
+	}
+	for _, p := range []string{"10.0.0.0/8", "100.64.0.0/10", "192.0.2.0/24", "240.0.0.0/4", "2001:db8::/32", "fc00::/7"} {
+		if !slices.Contains(bogons, p) {
+			t.Error("Error getting Bogons()", "Expected:", p, "Got:", bogons)
+		}
+	}
+	if bogons[0] != "0.0.0.0/8" || bogons[len(bogons)-1] != "ff00::/8" {
+		t.Error("Error getting Bogons()", "Expected IPv4 first in address order Got:", bogons)
+	}
+}
+
+func TestOverlappingBogons(t *testing.T) {
+	bogonTests := []struct {
+		cidr   string
+		result []string
+	}{
+		{cidr: "8.8.8.0/24", result: nil},
+		{cidr: "10.1.2.0/24", result: []string{"10.0.0.0/8"}},
+		{cidr: "192.0.0.0/22", result: []string{"192.0.0.0/24", "192.0.2.0/24"}},
+		{cidr: "172.0.0.0/8", result: []string{"172.16.0.0/12"}},
+		{cidr: "203.0.113.7/32", result: []string{"203.0.113.0/24"}},
+		{cidr: "0.0.0.0/0", result: subnetStrings(Bogons())[:15]},
+		{cidr: "2001:db8:1::/48", result: []string{"2001:db8::/32"}},
+		{cidr: "2606:4700::/32", result: nil},
+	}
+
+	for _, test := range bogonTests {
+		s, _ := ParseCIDR(test.cidr)
+		result := subnetStrings(s.OverlappingBogons())
+		if !slices.Equal(result, test.result) {
+			t.Error("Error getting .OverlappingBogons() for", test.cidr, "Expected:", test.result, "Got:", result)
+		}
+		if s.IsBogon() != (len(test.result) > 0) {
+			t.Error("Error getting .IsBogon() for", test.cidr, "Expected:", len(test.result) > 0, "Got:", s.IsBogon())
+		}
+	}
+}
+
+func TestRenderPrefixList(t *testing.T) {
+	subnets := parseSubnets(t, []string{"10.0.0.0/8", "192.0.2.1/32", "2001:db8::/32"})
+
+	renderTests := []struct {
+		format RouterFormat
+		result string
+	}{
+		{format: FormatPlain, result: "10.0.0.0/8\n192.0.2.1/32\n2001:db8::/32\n"},
+		{format: FormatCiscoPrefixList, result: "ip prefix-list BOGONS seq 5 permit 10.0.0.0/8 le 32\n" +
+			"ip prefix-list BOGONS seq 10 permit 192.0.2.1/32\n" +
+			"ipv6 prefix-list BOGONS seq 5 permit 2001:db8::/32 le 128\n"},
+		{format: FormatJunos, result: "policy-options {\n    route-filter-list BOGONS {\n" +
+			"        10.0.0.0/8 orlonger;\n        192.0.2.1/32 orlonger;\n        2001:db8::/32 orlonger;\n    }\n}\n"},
+		{format: FormatBIRD, result: "define BOGONS_v4 = [\n    10.0.0.0/8+,\n    192.0.2.1/32+\n];\n" +
+			"define BOGONS_v6 = [\n    2001:db8::/32+\n];\n"},
+	}
+
+	for _, test := range renderTests {
+		result, err := RenderPrefixList("BOGONS", subnets, test.format)
+		if err != nil || result != test.result {
+			t.Error("Error getting RenderPrefixList() for", test.format, "Expected:", test.result, "Got:", result, err)
+		}
+	}
+
+	if _, err := RenderPrefixList("BAD NAME", subnets, FormatJunos); err == nil {
+		t.Error("Error getting RenderPrefixList() for an invalid name", "Expected an error Got:", err)
+	}
+	if _, err := RenderPrefixList("BOGONS", subnets, RouterFormat(99)); err == nil {
+		t.Error("Error getting RenderPrefixList() for an unknown format", "Expected an error Got:", err)
+	}
+	if _, err := RenderPrefixList("BOGONS", []Subnet{{}}, FormatPlain); err == nil {
+		t.Error("Error getting RenderPrefixList() for an invalid subnet", "Expected an error Got:", err)
+	}
+}
@@ -0,0 +1,176 @@
+package netmath
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net/netip"
+)
+
+var (
+	sixToFourPrefix = mustParseCIDR("2002::/16")
+	teredoPrefix    = mustParseCIDR("2001::/32")
+)
+
+// Embed an IPv4 address in an RFC 6052 IPv4-embedded IPv6 prefix of length 32, 40, 48, 56, 64 or 96,
+// skipping the reserved u-octet (bits 64 to 71) ex. 64:ff9b::/96 with 192.0.2.33 -> 64:ff9b::c000:221
+func (s Subnet) EmbedIPv4(v4 netip.Addr) (netip.Addr, error) {
+	if err := checkEmbedPrefix(s); err != nil {
+		return netip.Addr{}, err
+	}
+	if !v4.Is4() {
+		return netip.Addr{}, fmt.Errorf("%w: %s is not IPv4", ErrInvalidAddr, v4)
+	}
+
+	b := s.Masked().Addr().As16()
+	src := v4.As4()
+	pos := s.Bits() / 8
+	for i := 0; i < 4; i++ {
+		if pos == 8 {
+			pos++
+		}
+		b[pos] = src[i]
+		pos++
+	}
+	return netip.AddrFrom16(b), nil
+}
+
+// Extract the IPv4 address embedded by EmbedIPv4 ex. 64:ff9b::/96 with 64:ff9b::c000:221 -> 192.0.2.33
+func (s Subnet) ExtractIPv4(addr netip.Addr) (netip.Addr, error) {
+	if err := checkEmbedPrefix(s); err != nil {
+		return netip.Addr{}, err
+	}
+	if !addr.Is6() || addr.Is4In6() || !s.Contains(addr) {
+		return netip.Addr{}, fmt.Errorf("%w: %s is not inside %s", ErrInvalidAddr, addr, s)
+	}
+	if addr.As16()[8] != 0 {
+		return netip.Addr{}, fmt.Errorf("%w: the u-octet of %s must be zero", ErrInvalidAddr, addr)
+	}
+
+	b := addr.As16()
+	var v4 [4]byte
+	pos := s.Bits() / 8
+	for i := 0; i < 4; i++ {
+		if pos == 8 {
+			pos++
+		}
+		v4[i] = b[pos]
+		pos++
+	}
+	return netip.AddrFrom4(v4), nil
+}
+
+func checkEmbedPrefix(s Subnet) error {
+	if !s.IsValid() || !s.Addr().Is6() {
+		return ErrInvalidPrefix
+	}
+	switch s.Bits() {
+	case 32, 40, 48, 56, 64:
+		return nil
+	case 96:
+		// The u-octet falls inside a /96, shorter prefixes never reach it
+		if s.Masked().Addr().As16()[8] != 0 {
+			return fmt.Errorf("%w: the u-octet of %s must be zero", ErrInvalidPrefix, s)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: IPv4 can only be embedded in a /32, /40, /48, /56, /64 or /96, got /%d", ErrInvalidBits, s.Bits())
+}
+
+// Get the 6to4 /48 of an IPv4 address ex. 192.0.2.1 -> 2002:c000:201::/48
+func Encode6to4(v4 netip.Addr) (Subnet, error) {
+	if !v4.Is4() {
+		return Subnet{}, fmt.Errorf("%w: %s is not IPv4", ErrInvalidAddr, v4)
+	}
+
+	var b [16]byte
+	b[0], b[1] = 0x20, 0x02
+	copy(b[2:6], v4.AsSlice())
+	return NewSubnet(netip.PrefixFrom(netip.AddrFrom16(b), 48)), nil
+}
+
+// Get the IPv4 address of a 6to4 address ex. 2002:c000:201::1 -> 192.0.2.1
+func Decode6to4(addr netip.Addr) (netip.Addr, error) {
+	if !addr.Is6() || addr.Is4In6() || !sixToFourPrefix.Contains(addr) {
+		return netip.Addr{}, fmt.Errorf("%w: %s is not a 6to4 address", ErrInvalidAddr, addr)
+	}
+
+	b := addr.As16()
+	return netip.AddrFrom4([4]byte(b[2:6])), nil
+}
+
+// Fields of a Teredo address, with the port and client in their plain (not obfuscated) form
+type Teredo struct {
+	Server netip.Addr
+	Flags  uint16 // 0x8000 is the cone flag
+	Port   uint16
+	Client netip.Addr
+}
+
+// Decode a Teredo address ex. 2001:0:4136:e378:8000:63bf:3fff:fdd2 -> server 65.54.227.120, port 40000, client 192.0.2.45
+func DecodeTeredo(addr netip.Addr) (Teredo, error) {
+	if !addr.Is6() || addr.Is4In6() || !teredoPrefix.Contains(addr) {
+		return Teredo{}, fmt.Errorf("%w: %s is not a Teredo address", ErrInvalidAddr, addr)
+	}
+
+	b := addr.As16()
+	client := [4]byte(b[12:16])
+	for i := range client {
+		client[i] ^= 0xff
+	}
+
+	return Teredo{
+		Server: netip.AddrFrom4([4]byte(b[4:8])),
+		Flags:  binary.BigEndian.Uint16(b[8:10]),
+		Port:   binary.BigEndian.Uint16(b[10:12]) ^ 0xffff,
+		Client: netip.AddrFrom4(client),
+	}, nil
+}
+
+// Encode the Teredo address, obfuscating the port and client
+func (t Teredo) Addr() (netip.Addr, error) {
+	if !t.Server.Is4() || !t.Client.Is4() {
+		return netip.Addr{}, fmt.Errorf("%w: Teredo server and client must be IPv4", ErrInvalidAddr)
+	}
+
+	var b [16]byte
+	b[0], b[1] = 0x20, 0x01
+	copy(b[4:8], t.Server.AsSlice())
+	binary.BigEndian.PutUint16(b[8:10], t.Flags)
+	binary.BigEndian.PutUint16(b[10:12], t.Port^0xffff)
+	client := t.Client.As4()
+	for i := range client {
+		b[12+i] = client[i] ^ 0xff
+	}
+	return netip.AddrFrom16(b), nil
+}
+
+// Get the ISATAP address of an IPv4 address in a /64, with the universal bit set for globally reachable addresses
+// ex. 2001:db8::/64 with 10.0.0.1 -> 2001:db8::5efe:a00:1, 8.8.8.8 -> 2001:db8::200:5efe:808:808
+func (s Subnet) ISATAP(v4 netip.Addr) (netip.Addr, error) {
+	prefix, err := interfacePrefix(s)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	if !v4.Is4() {
+		return netip.Addr{}, fmt.Errorf("%w: %s is not IPv4", ErrInvalidAddr, v4)
+	}
+
+	iid := uint64(0x00005efe)<<32 | uint64(binary.BigEndian.Uint32(v4.AsSlice()))
+	if sp, ok := ClassifyAddr(v4); !ok || sp.GloballyReachable {
+		iid |= 0x0200000000000000
+	}
+	return interfaceAddr(prefix, iid), nil
+}
+
+// Get the IPv4 address in an ISATAP interface identifier ex. fe80::5efe:c000:201 -> 192.0.2.1
+func DecodeISATAP(addr netip.Addr) (netip.Addr, error) {
+	if !addr.Is6() || addr.Is4In6() {
+		return netip.Addr{}, ErrInvalidAddr
+	}
+
+	b := addr.As16()
+	if b[8]&^0x02 != 0 || b[9] != 0 || b[10] != 0x5e || b[11] != 0xfe {
+		return netip.Addr{}, fmt.Errorf("%w: %s has no ISATAP interface identifier", ErrInvalidAddr, addr)
+	}
+	return netip.AddrFrom4([4]byte(b[12:16])), nil
+}
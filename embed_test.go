@@ -0,0 +1,154 @@
+package netmath
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+)
+
+func TestEmbedIPv4(t *testing.T) {
+	// Examples from RFC 6052 section 2.4 embedding 192.0.2.33
+	embedTests := []struct {
+		cidr   string
+		result string
+	}{
+		{cidr: "2001:db8::/32", result: "2001:db8:c000:221::"},
+		{cidr: "2001:db8:100::/40", result: "2001:db8:1c0:2:21::"},
+		{cidr: "2001:db8:122::/48", result: "2001:db8:122:c000:2:2100::"},
+		{cidr: "2001:db8:122:300::/56", result: "2001:db8:122:3c0:0:221::"},
+		{cidr: "2001:db8:122:344::/64", result: "2001:db8:122:344:c0:2:2100:0"},
+		{cidr: "2001:db8:122:344::/96", result: "2001:db8:122:344::c000:221"},
+		{cidr: "64:ff9b::/96", result: "64:ff9b::c000:221"},
+	}
+
+	v4 := netip.MustParseAddr("192.0.2.33")
+	for _, test := range embedTests {
+		s, _ := ParseCIDR(test.cidr)
+		addr, err := s.EmbedIPv4(v4)
+		if err != nil || addr.String() != test.result {
+			t.Error("Error getting .EmbedIPv4() for", test.cidr, "Expected:", test.result, "Got:", addr, err)
+			continue
+		}
+
+		extracted, err := s.ExtractIPv4(addr)
+		if err != nil || extracted != v4 {
+			t.Error("Error getting .ExtractIPv4() for", test.cidr, addr, "Expected:", v4, "Got:", extracted, err)
+		}
+	}
+
+	errorTests := []struct {
+		cidr string
+		addr string
+		err  error
+	}{
+		{cidr: "2001:db8::/36", addr: "192.0.2.33", err: ErrInvalidBits},
+		{cidr: "10.0.0.0/8", addr: "192.0.2.33", err: ErrInvalidPrefix},
+		{cidr: "64:ff9b::/96", addr: "2001:db8::1", err: ErrInvalidAddr},
+		{cidr: "64:ff9b:0:0:100::/96", addr: "192.0.2.33", err: ErrInvalidPrefix}, // u-octet set
+	}
+	for _, test := range errorTests {
+		s, _ := ParseCIDR(test.cidr)
+		if _, err := s.EmbedIPv4(netip.MustParseAddr(test.addr)); !errors.Is(err, test.err) {
+			t.Error("Error getting .EmbedIPv4() for", test.cidr, test.addr, "Expected:", test.err, "Got:", err)
+		}
+	}
+
+	extractTests := []struct {
+		cidr string
+		addr string
+		err  error
+	}{
+		{cidr: "64:ff9b::/96", addr: "2001:db8::1", err: ErrInvalidAddr}, // Outside the prefix
+		{cidr: "64:ff9b:0:0:100::/96", addr: "64:ff9b::100:0:c000:221", err: ErrInvalidPrefix},
+		{cidr: "2001:db8:122:344::/64", addr: "2001:db8:122:344:1c0:2:2100:0", err: ErrInvalidAddr}, // u-octet set
+	}
+	for _, test := range extractTests {
+		s, _ := ParseCIDR(test.cidr)
+		if _, err := s.ExtractIPv4(netip.MustParseAddr(test.addr)); !errors.Is(err, test.err) {
+			t.Error("Error getting .ExtractIPv4() for", test.cidr, test.addr, "Expected:", test.err, "Got:", err)
+		}
+	}
+}
+
+func Test6to4(t *testing.T) {
+	s, err := Encode6to4(netip.MustParseAddr("192.0.2.1"))
+	if err != nil || s.String() != "2002:c000:201::/48" {
+		t.Error("Error getting Encode6to4() for 192.0.2.1", "Expected: 2002:c000:201::/48 Got:", s, err)
+	}
+
+	v4, err := Decode6to4(netip.MustParseAddr("2002:c000:201:10::1"))
+	if err != nil || v4.String() != "192.0.2.1" {
+		t.Error("Error getting Decode6to4() for 2002:c000:201:10::1", "Expected: 192.0.2.1 Got:", v4, err)
+	}
+
+	if _, err := Decode6to4(netip.MustParseAddr("2001:db8::1")); err == nil {
+		t.Error("Error getting Decode6to4() for 2001:db8::1", "Expected an error Got:", err)
+	}
+	if _, err := Encode6to4(netip.MustParseAddr("2001:db8::1")); err == nil {
+		t.Error("Error getting Encode6to4() for 2001:db8::1", "Expected an error Got:", err)
+	}
+}
+
+func TestTeredo(t *testing.T) {
+	addr := netip.MustParseAddr("2001:0:4136:e378:8000:63bf:3fff:fdd2")
+	teredo, err := DecodeTeredo(addr)
+	if err != nil {
+		t.Fatal("Error getting DecodeTeredo() for", addr, "Expected: <nil> Got:", err)
+	}
+
+	expected := Teredo{
+		Server: netip.MustParseAddr("65.54.227.120"),
+		Flags:  0x8000,
+		Port:   40000,
+		Client: netip.MustParseAddr("192.0.2.45"),
+	}
+	if teredo != expected {
+		t.Error("Error getting DecodeTeredo() for", addr, "Expected:", expected, "Got:", teredo)
+	}
+
+	encoded, err := teredo.Addr()
+	if err != nil || encoded != addr {
+		t.Error("Error getting Teredo.Addr()", "Expected:", addr, "Got:", encoded, err)
+	}
+
+	if _, err := DecodeTeredo(netip.MustParseAddr("2002::1")); err == nil {
+		t.Error("Error getting DecodeTeredo() for 2002::1", "Expected an error Got:", err)
+	}
+	if _, err := (Teredo{}).Addr(); err == nil {
+		t.Error("Error getting Teredo.Addr() for the zero Teredo", "Expected an error Got:", err)
+	}
+}
+
+func TestISATAP(t *testing.T) {
+	isatapTests := []struct {
+		cidr   string
+		v4     string
+		result string
+	}{
+		{cidr: "2001:db8::/64", v4: "10.0.0.1", result: "2001:db8::5efe:a00:1"},
+		{cidr: "2001:db8::/64", v4: "8.8.8.8", result: "2001:db8::200:5efe:808:808"},
+		{cidr: "fe80::/64", v4: "192.168.1.1", result: "fe80::5efe:c0a8:101"},
+	}
+
+	for _, test := range isatapTests {
+		s, _ := ParseCIDR(test.cidr)
+		addr, err := s.ISATAP(netip.MustParseAddr(test.v4))
+		if err != nil || addr.String() != test.result {
+			t.Error("Error getting .ISATAP() for", test.cidr, test.v4, "Expected:", test.result, "Got:", addr, err)
+			continue
+		}
+
+		v4, err := DecodeISATAP(addr)
+		if err != nil || v4.String() != test.v4 {
+			t.Error("Error getting DecodeISATAP() for", addr, "Expected:", test.v4, "Got:", v4, err)
+		}
+	}
+
+	if _, err := DecodeISATAP(netip.MustParseAddr("2001:db8::1")); err == nil {
+		t.Error("Error getting DecodeISATAP() for 2001:db8::1", "Expected an error Got:", err)
+	}
+	s, _ := ParseCIDR("2001:db8::/48")
+	if _, err := s.ISATAP(netip.MustParseAddr("10.0.0.1")); err == nil {
+		t.Error("Error getting .ISATAP() for", s, "Expected an error Got:", err)
+	}
+}
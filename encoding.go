@@ -0,0 +1,111 @@
+package netmath
+
+import (
+	"net/netip"
+	"strings"
+)
+
+// Encode the subnet in the <ip-address>/<bits> format, keeping host bits. The zero Subnet encodes as an empty string.
+func (s Subnet) MarshalText() ([]byte, error) {
+	if !s.IsValid() {
+		return []byte{}, nil
+	}
+	return []byte(s.String()), nil
+}
+
+// Decode a subnet in the <ip-address>/<bits>, <ip-address>/<subnet-mask> or long <ip-address>, <subnet-mask> format.
+// An empty string decodes to the zero Subnet. Failures are reported as a *ParseError.
+func (s *Subnet) UnmarshalText(text []byte) error {
+	str := string(text)
+	if str == "" {
+		*s = Subnet{}
+		return nil
+	}
+
+	var snet Subnet
+	var err error
+	if addrStr, suffix, ok := strings.Cut(str, "/"); ok && strings.ContainsAny(suffix, ".:") {
+		snet, err = Parse(addrStr, suffix)
+		err = relocatePartError(err, str, 0, len(addrStr)+1)
+	} else if ok {
+		snet, err = ParseCIDR(str)
+	} else {
+		snet, err = parseLongForm(str)
+	}
+	if err != nil {
+		return err
+	}
+
+	*s = snet
+	return nil
+}
+
+// Parse <ip-address>, <subnet-mask> where the comma is optional
+func parseLongForm(s string) (Subnet, error) {
+	addrStr, maskStr, addrOffset, maskOffset, err := splitAddrAndMask(s)
+	if err != nil {
+		return Subnet{}, err
+	}
+
+	snet, err := Parse(addrStr, maskStr)
+	return snet, relocatePartError(err, s, addrOffset, maskOffset)
+}
+
+// Addressing details of a subnet, with counts as decimal strings since they can exceed 64 bits
+type SubnetInfo struct {
+	Subnet      Subnet     `json:"subnet" yaml:"subnet"`
+	Version     int        `json:"version" yaml:"version"`
+	Bits        int        `json:"bits" yaml:"bits"`
+	Network     netip.Addr `json:"network" yaml:"network"`
+	Broadcast   netip.Addr `json:"broadcast" yaml:"broadcast"`
+	Mask        netip.Addr `json:"mask" yaml:"mask"`
+	Wildcard    netip.Addr `json:"wildcard" yaml:"wildcard"`
+	FirstUsable netip.Addr `json:"first_usable" yaml:"first_usable"`
+	LastUsable  netip.Addr `json:"last_usable" yaml:"last_usable"`
+	Count       string     `json:"count" yaml:"count"`
+	UsableCount string     `json:"usable_count" yaml:"usable_count"`
+}
+
+// Get the addressing details of the subnet ex. 192.168.20.15/23 -> network 192.168.20.0, broadcast 192.168.21.255 ...
+func (s Subnet) Info() (SubnetInfo, error) {
+	if !s.IsValid() {
+		return SubnetInfo{}, ErrInvalidPrefix
+	}
+
+	info := SubnetInfo{Subnet: s, Version: 6, Bits: s.Bits()}
+	if s.Addr().Is4() {
+		info.Version = 4
+	}
+
+	var err error
+	if info.Network, err = s.Network(); err != nil {
+		return SubnetInfo{}, err
+	}
+	if info.Broadcast, err = s.Broadcast(); err != nil {
+		return SubnetInfo{}, err
+	}
+	if info.Mask, err = s.Mask(); err != nil {
+		return SubnetInfo{}, err
+	}
+	if info.Wildcard, err = s.Wildcard(); err != nil {
+		return SubnetInfo{}, err
+	}
+
+	usable, err := s.UsableRange()
+	if err != nil {
+		return SubnetInfo{}, err
+	}
+	info.FirstUsable, info.LastUsable = usable.From, usable.To
+
+	count, err := s.CountExact()
+	if err != nil {
+		return SubnetInfo{}, err
+	}
+	usableCount, err := s.CountUsable()
+	if err != nil {
+		return SubnetInfo{}, err
+	}
+	info.Count, info.UsableCount = count.String(), usableCount.String()
+
+	return info, nil
+}
@@ -0,0 +1,128 @@
+package netmath
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestMarshalText(t *testing.T) {
+	marshalTests := []struct {
+		cidr   string
+		result string
+	}{
+		{cidr: "192.168.20.15/23", result: "192.168.20.15/23"},
+		{cidr: "10.0.0.0/8", result: "10.0.0.0/8"},
+		{cidr: "2001:db8::/32", result: "2001:db8::/32"},
+	}
+
+	for _, test := range marshalTests {
+		s, _ := ParseCIDR(test.cidr)
+		text, err := s.MarshalText()
+		if err != nil || string(text) != test.result {
+			t.Error("Error getting .MarshalText() for", test.cidr, "Expected:", test.result, "Got:", string(text), err)
+		}
+	}
+
+	if text, _ := (Subnet{}).MarshalText(); string(text) != "" {
+		t.Error("Error getting .MarshalText() for the zero Subnet", "Expected: \"\" Got:", string(text))
+	}
+}
+
+func TestUnmarshalText(t *testing.T) {
+	unmarshalTests := []struct {
+		text   string
+		result string
+		offset int
+		err    error
+	}{
+		{text: "192.168.20.15/23", result: "192.168.20.15/23"},
+		{text: "192.168.20.15, 255.255.254.0", result: "192.168.20.15/23"},
+		{text: "192.168.20.15 255.255.254.0", result: "192.168.20.15/23"},
+		{text: "192.168.20.15/255.255.254.0", result: "192.168.20.15/23"},
+		{text: "2001:db8::1, ffff:ffff::", result: "2001:db8::1/32"},
+		{text: "", result: "invalid Prefix"},
+		{text: "192.168.20.15", offset: 13, err: ErrSyntax},
+		{text: "192.168.20.15, 255.0.255.0", offset: 21, err: ErrNonContiguousMask},
+		{text: "192.168.20.15/255.0.255.0", offset: 20, err: ErrNonContiguousMask},
+		{text: "192.168.300.15, 255.0.0.0", offset: 8, err: ErrOctetOutOfRange},
+		{text: "10.0.0.0 255.0.0.0 extra", offset: 19, err: ErrSyntax},
+		{text: "1.1.1.1, 1.1.1.1, 1", offset: 18, err: ErrSyntax},
+		{text: "  10.0.0.300 255.0.0.0", offset: 9, err: ErrOctetOutOfRange},
+	}
+
+	for _, test := range unmarshalTests {
+		var s Subnet
+		err := s.UnmarshalText([]byte(test.text))
+		if test.err != nil {
+			var pe *ParseError
+			if !errors.As(err, &pe) || !errors.Is(err, test.err) || pe.Offset != test.offset {
+				t.Error("Error getting .UnmarshalText() for", test.text, "Expected:", test.err, "at offset", test.offset, "Got:", err)
+			}
+			continue
+		}
+		if err != nil || s.String() != test.result {
+			t.Error("Error getting .UnmarshalText() for", test.text, "Expected:", test.result, "Got:", s, err)
+		}
+	}
+}
+
+func TestSubnetJSON(t *testing.T) {
+	type doc struct {
+		Subnet Subnet `json:"subnet"`
+	}
+
+	var d doc
+	if err := json.Unmarshal([]byte(`{"subnet":"10.1.1.0 255.255.255.0"}`), &d); err != nil || d.Subnet.String() != "10.1.1.0/24" {
+		t.Error("Error unmarshaling JSON", "Expected:", "10.1.1.0/24", "Got:", d.Subnet, err)
+	}
+
+	data, err := json.Marshal(d)
+	if err != nil || string(data) != `{"subnet":"10.1.1.0/24"}` {
+		t.Error("Error marshaling JSON", "Expected:", `{"subnet":"10.1.1.0/24"}`, "Got:", string(data), err)
+	}
+}
+
+func TestInfo(t *testing.T) {
+	infoTests := []struct {
+		cidr   string
+		result string
+	}{
+		{
+			cidr: "192.168.20.15/23",
+			result: `{"subnet":"192.168.20.15/23","version":4,"bits":23,"network":"192.168.20.0","broadcast":"192.168.21.255",` +
+				`"mask":"255.255.254.0","wildcard":"0.0.1.255","first_usable":"192.168.20.1","last_usable":"192.168.21.254",` +
+				`"count":"512","usable_count":"510"}`,
+		},
+		{
+			cidr: "10.0.0.0/31",
+			result: `{"subnet":"10.0.0.0/31","version":4,"bits":31,"network":"10.0.0.0","broadcast":"10.0.0.1",` +
+				`"mask":"255.255.255.254","wildcard":"0.0.0.1","first_usable":"10.0.0.0","last_usable":"10.0.0.1",` +
+				`"count":"2","usable_count":"2"}`,
+		},
+		{
+			cidr: "::/0",
+			result: `{"subnet":"::/0","version":6,"bits":0,"network":"::","broadcast":"ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff",` +
+				`"mask":"::","wildcard":"ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff","first_usable":"::1","last_usable":"ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff",` +
+				`"count":"340282366920938463463374607431768211456","usable_count":"340282366920938463463374607431768211455"}`,
+		},
+	}
+
+	for _, test := range infoTests {
+		s, _ := ParseCIDR(test.cidr)
+		info, err := s.Info()
+		if err != nil {
+			t.Error("Error getting .Info() for", test.cidr, "Expected: <nil> Got:", err)
+			continue
+		}
+
+		data, err := json.Marshal(info)
+		if err != nil || string(data) != test.result {
+			t.Error("Error getting .Info() for", test.cidr, "Expected:", test.result, "Got:", string(data), err)
+		}
+	}
+
+	if _, err := (Subnet{}).Info(); err == nil {
+		t.Error("Error getting .Info() for the zero Subnet", "Expected an error Got:", err)
+	}
+}
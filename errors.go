@@ -0,0 +1,199 @@
+package netmath
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// What was being parsed when a ParseError occurred
+var (
+	ErrInvalidAddr     = errors.New("invalid host address")
+	ErrInvalidMask     = errors.New("invalid subnet mask")
+	ErrInvalidWildcard = errors.New("invalid wildcard mask")
+	ErrInvalidPrefix   = errors.New("invalid subnet")
+	ErrInvalidRange    = errors.New("invalid address range")
+	ErrInvalidBits     = errors.New("invalid bit length")
+)
+
+// Why a ParseError occurred
+var (
+	ErrSyntax            = errors.New("malformed input")
+	ErrOctetOutOfRange   = errors.New("octet out of range")
+	ErrNonContiguousMask = errors.New("non-contiguous mask")
+	ErrFamilyMismatch    = errors.New("address family mismatch")
+	ErrBitsOutOfRange    = errors.New("prefix length out of range")
+	ErrReversedRange     = errors.New("range ends before it starts")
+	ErrHostBitsSet       = errors.New("host bits set")
+)
+
+// Why planning or allocating address space failed
+var (
+	ErrInvalidCount = errors.New("invalid count")
+	ErrNoSpace      = errors.New("no free space")
+	ErrOverlap      = errors.New("overlapping address space")
+	ErrOutsidePools = errors.New("outside of the pools")
+	ErrNotAllocated = errors.New("not allocated")
+)
+
+// Error describing where and why an input could not be parsed.
+// Both Err and Reason can be matched with errors.Is.
+type ParseError struct {
+	Input  string // Offending input
+	Offset int    // Byte offset in Input where the problem was found, -1 when unknown
+	Err    error  // What was being parsed ex. ErrInvalidMask
+	Reason error  // Why it failed ex. ErrNonContiguousMask
+}
+
+func newParseError(input string, offset int, err error, reason error) *ParseError {
+	return &ParseError{Input: input, Offset: offset, Err: err, Reason: reason}
+}
+
+func (e *ParseError) Error() string {
+	if e.Offset < 0 {
+		return fmt.Sprintf("%v %q: %v", e.Err, e.Input, e.Reason)
+	}
+	return fmt.Sprintf("%v %q: %v at offset %d", e.Err, e.Input, e.Reason, e.Offset)
+}
+
+func (e *ParseError) Unwrap() []error {
+	return []error{e.Err, e.Reason}
+}
+
+// Find where and why a string fails to parse in the <ip-address>/<bits> format
+func locatePrefixError(s string) *ParseError {
+	i := strings.LastIndexByte(s, '/')
+	if i < 0 {
+		return newParseError(s, len(s), ErrInvalidPrefix, ErrSyntax)
+	}
+
+	if _, err := netip.ParseAddr(s[:i]); err != nil || strings.Contains(s[:i], "%") {
+		offset, reason := locateAddrError(s[:i])
+		return newParseError(s, offset, ErrInvalidPrefix, reason)
+	}
+
+	bitsStr := s[i+1:]
+	if bitsStr == "" || (len(bitsStr) > 1 && bitsStr[0] == '0') {
+		return newParseError(s, i+1, ErrInvalidPrefix, ErrSyntax)
+	}
+	for j := 0; j < len(bitsStr); j++ {
+		if bitsStr[j] < '0' || bitsStr[j] > '9' {
+			return newParseError(s, i+1+j, ErrInvalidPrefix, ErrSyntax)
+		}
+	}
+	return newParseError(s, i+1, ErrInvalidPrefix, ErrBitsOutOfRange)
+}
+
+// Find where and why an address string fails to parse
+func locateAddrError(s string) (int, error) {
+	if i := strings.IndexByte(s, '%'); i >= 0 {
+		return i, ErrSyntax
+	}
+	if strings.Contains(s, ":") {
+		return locateIPv6Error(s)
+	}
+	return locateIPv4Error(s)
+}
+
+func locateIPv4Error(s string) (int, error) {
+	start, fields := 0, 0
+	for i := 0; i <= len(s); i++ {
+		if i < len(s) && s[i] != '.' {
+			if s[i] < '0' || s[i] > '9' {
+				return i, ErrSyntax
+			}
+			continue
+		}
+
+		fields++
+		field := s[start:i]
+		switch {
+		case fields > 4 || field == "":
+			return start, ErrSyntax
+		case len(field) > 1 && field[0] == '0':
+			return start, ErrSyntax
+		case len(field) > 3 || (len(field) == 3 && field > "255"):
+			return start, ErrOctetOutOfRange
+		}
+		start = i + 1
+	}
+
+	if fields < 4 {
+		return len(s), ErrSyntax
+	}
+	return -1, ErrSyntax
+}
+
+func locateIPv6Error(s string) (int, error) {
+	start := 0
+	for i := 0; i <= len(s); i++ {
+		if i < len(s) && s[i] != ':' {
+			if !isHex(s[i]) && s[i] != '.' {
+				return i, ErrSyntax
+			}
+			continue
+		}
+
+		if group := s[start:i]; !strings.Contains(group, ".") && len(group) > 4 {
+			return start, ErrOctetOutOfRange
+		}
+		start = i + 1
+	}
+	return -1, ErrSyntax
+}
+
+func isHex(c byte) bool {
+	return ('0' <= c && c <= '9') || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
+}
+
+// Byte offset in the textual address s of the octet or hextet that holds the bit, -1 when unknown
+func locateBit(s string, bit int) int {
+	if !strings.Contains(s, ":") {
+		start := 0
+		for field := 0; field < bit/8; field++ {
+			i := strings.IndexByte(s[start:], '.')
+			if i < 0 {
+				return -1
+			}
+			start += i + 1
+		}
+		return start
+	}
+
+	// Map every hextet to its offset, groups hidden by "::" point at the "::"
+	left, right, gap := strings.Cut(s, "::")
+	var offsets []int
+	leftOffsets := fieldOffsets(left, 0)
+	rightOffsets := fieldOffsets(right, len(left)+2)
+	offsets = append(offsets, leftOffsets...)
+	if gap {
+		for i := len(leftOffsets) + len(rightOffsets); i < 8; i++ {
+			offsets = append(offsets, len(left))
+		}
+	}
+	offsets = append(offsets, rightOffsets...)
+
+	if bit/16 >= len(offsets) {
+		return -1
+	}
+	return offsets[bit/16]
+}
+
+// Offsets of the colon separated groups of s, an embedded IPv4 address counts as two groups
+func fieldOffsets(s string, base int) []int {
+	if s == "" {
+		return nil
+	}
+
+	var offsets []int
+	start := 0
+	for _, group := range strings.Split(s, ":") {
+		offsets = append(offsets, base+start)
+		if strings.Contains(group, ".") {
+			offsets = append(offsets, base+start)
+		}
+		start += len(group) + 1
+	}
+	return offsets
+}
@@ -0,0 +1,93 @@
+package netmath
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseError(t *testing.T) {
+	errorTests := []struct {
+		addr   string
+		mask   string
+		input  string
+		offset int
+		err    error
+		reason error
+	}{
+		{addr: "255.255.255.256", mask: "0.0.0.0", input: "255.255.255.256", offset: 12, err: ErrInvalidAddr, reason: ErrOctetOutOfRange},
+		{addr: "10.0.-1.0", mask: "0.0.0.0", input: "10.0.-1.0", offset: 5, err: ErrInvalidAddr, reason: ErrSyntax},
+		{addr: "10.0.0", mask: "0.0.0.0", input: "10.0.0", offset: 6, err: ErrInvalidAddr, reason: ErrSyntax},
+		{addr: "10.0.0.01", mask: "0.0.0.0", input: "10.0.0.01", offset: 7, err: ErrInvalidAddr, reason: ErrSyntax},
+		{addr: "2001:db8::12345", mask: "::", input: "2001:db8::12345", offset: 10, err: ErrInvalidAddr, reason: ErrOctetOutOfRange},
+		{addr: "0.0.0.0", mask: "255.255.0.255", input: "255.255.0.255", offset: 10, err: ErrInvalidMask, reason: ErrNonContiguousMask},
+		{addr: "0.0.0.0", mask: "255.255.255.251", input: "255.255.255.251", offset: 12, err: ErrInvalidMask, reason: ErrNonContiguousMask},
+		{addr: "0.0.0.0", mask: "255.255.256.0", input: "255.255.256.0", offset: 8, err: ErrInvalidMask, reason: ErrOctetOutOfRange},
+		{addr: "::", mask: "ffff::ffff", input: "ffff::ffff", offset: 6, err: ErrInvalidMask, reason: ErrNonContiguousMask},
+		{addr: "::", mask: "ffff:0:ffff::", input: "ffff:0:ffff::", offset: 7, err: ErrInvalidMask, reason: ErrNonContiguousMask},
+		{addr: "10.0.0.0", mask: "ffff:ffff:ffff::", input: "ffff:ffff:ffff::", offset: 10, err: ErrInvalidMask, reason: ErrBitsOutOfRange},
+	}
+
+	for _, test := range errorTests {
+		_, err := Parse(test.addr, test.mask)
+
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Error("Error parsing", test.addr, "and", test.mask, "Expected a *ParseError Got:", err)
+			continue
+		}
+		if pe.Input != test.input || pe.Offset != test.offset || !errors.Is(err, test.err) || !errors.Is(err, test.reason) {
+			t.Error("Error parsing", test.addr, "and", test.mask, "Expected:", test.input, test.offset, test.err, test.reason, "Got:", pe.Input, pe.Offset, pe.Err, pe.Reason)
+		}
+	}
+}
+
+func TestParseCIDRError(t *testing.T) {
+	errorTests := []struct {
+		snet   string
+		offset int
+		reason error
+	}{
+		{snet: "10.0.0.0", offset: 8, reason: ErrSyntax},
+		{snet: "10.0.0.0/33", offset: 9, reason: ErrBitsOutOfRange},
+		{snet: "10.0.0.0/2x", offset: 10, reason: ErrSyntax},
+		{snet: "10.0.0.0/", offset: 9, reason: ErrSyntax},
+		{snet: "10.300.0.0/8", offset: 3, reason: ErrOctetOutOfRange},
+		{snet: "2001:db8::/129", offset: 11, reason: ErrBitsOutOfRange},
+		{snet: "fe80::1%eth0/64", offset: 7, reason: ErrSyntax},
+	}
+
+	for _, test := range errorTests {
+		_, err := ParseCIDR(test.snet)
+
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Error("Error parsing", test.snet, "Expected a *ParseError Got:", err)
+			continue
+		}
+		if pe.Input != test.snet || pe.Offset != test.offset || !errors.Is(err, ErrInvalidPrefix) || !errors.Is(err, test.reason) {
+			t.Error("Error parsing", test.snet, "Expected:", test.offset, test.reason, "Got:", pe.Offset, pe.Reason)
+		}
+	}
+
+	want := `invalid subnet mask "255.255.0.255": non-contiguous mask at offset 10`
+	if _, err := Parse("0.0.0.0", "255.255.0.255"); err == nil || err.Error() != want {
+		t.Error("Error formatting a ParseError, Expected:", want, "Got:", err)
+	}
+}
+
+func TestSubnetErrors(t *testing.T) {
+	var s Subnet
+
+	if _, err := s.Mask(); !errors.Is(err, ErrInvalidBits) {
+		t.Error("Error getting .Mask() for the zero Subnet", "Expected:", ErrInvalidBits, "Got:", err)
+	}
+	if _, err := s.Network(); !errors.Is(err, ErrInvalidBits) {
+		t.Error("Error getting .Network() for the zero Subnet", "Expected:", ErrInvalidBits, "Got:", err)
+	}
+	if _, err := s.Broadcast(); !errors.Is(err, ErrInvalidBits) {
+		t.Error("Error getting .Broadcast() for the zero Subnet", "Expected:", ErrInvalidBits, "Got:", err)
+	}
+	if _, err := s.Wildcard(); !errors.Is(err, ErrInvalidBits) {
+		t.Error("Error getting .Wildcard() for the zero Subnet", "Expected:", ErrInvalidBits, "Got:", err)
+	}
+}
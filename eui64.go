@@ -0,0 +1,100 @@
+package netmath
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/netip"
+)
+
+// Get the modified EUI-64 SLAAC address of a MAC in a /64 ex. 2001:db8::/64 with 00:11:22:33:44:55 -> 2001:db8::211:22ff:fe33:4455.
+// Both 48 bit MACs and 64 bit EUI-64 identifiers are accepted.
+func (s Subnet) EUI64(mac net.HardwareAddr) (netip.Addr, error) {
+	prefix, err := interfacePrefix(s)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+
+	var iid [8]byte
+	switch len(mac) {
+	case 6:
+		copy(iid[:3], mac[:3])
+		iid[3], iid[4] = 0xff, 0xfe
+		copy(iid[5:], mac[3:])
+	case 8:
+		copy(iid[:], mac)
+	default:
+		return netip.Addr{}, fmt.Errorf("invalid MAC address length %d", len(mac))
+	}
+	// Invert the universal/local bit
+	iid[0] ^= 0x02
+
+	return interfaceAddr(prefix, binary.BigEndian.Uint64(iid[:])), nil
+}
+
+// Get the 48 bit MAC address embedded in a modified EUI-64 address ex. 2001:db8::211:22ff:fe33:4455 -> 00:11:22:33:44:55
+func MACFromEUI64(addr netip.Addr) (net.HardwareAddr, error) {
+	if !addr.Is6() || addr.Is4In6() {
+		return nil, ErrInvalidAddr
+	}
+
+	b := addr.As16()
+	if b[11] != 0xff || b[12] != 0xfe {
+		return nil, fmt.Errorf("%s has no EUI-64 interface identifier", addr)
+	}
+
+	mac := net.HardwareAddr{b[8] ^ 0x02, b[9], b[10], b[13], b[14], b[15]}
+	return mac, nil
+}
+
+// Get the RFC 7217 stable opaque address of an interface in a /64, hashing the prefix, interface name, network ID,
+// DAD counter and secret key with SHA-256. A counter that produces a reserved interface identifier is incremented,
+// so the counter actually used is returned alongside the address. networkID may be nil.
+func (s Subnet) StableAddr(secret []byte, iface string, networkID []byte, dadCounter uint8) (netip.Addr, uint8, error) {
+	prefix, err := interfacePrefix(s)
+	if err != nil {
+		return netip.Addr{}, 0, err
+	}
+	if len(secret) < 16 {
+		return netip.Addr{}, 0, fmt.Errorf("secret key must be at least 128 bits")
+	}
+
+	pb := prefix.As16()
+	for counter := int(dadCounter); counter <= 0xff; counter++ {
+		h := sha256.New()
+		h.Write(pb[:8])
+		h.Write([]byte(iface))
+		h.Write(networkID)
+		h.Write([]byte{byte(counter)})
+		h.Write(secret)
+
+		iid := binary.BigEndian.Uint64(h.Sum(nil)[:8])
+		if !isReservedIID(iid) {
+			return interfaceAddr(prefix, iid), uint8(counter), nil
+		}
+	}
+
+	return netip.Addr{}, 0, fmt.Errorf("no unreserved interface identifier left")
+}
+
+// Check the subnet is an IPv6 /64 and get its network address
+func interfacePrefix(s Subnet) (netip.Addr, error) {
+	if !s.IsValid() || !s.Addr().Is6() || s.Bits() != 64 {
+		return netip.Addr{}, fmt.Errorf("%w: interface identifiers need an IPv6 /64, got %s", ErrInvalidPrefix, s)
+	}
+	return s.Masked().Addr(), nil
+}
+
+func interfaceAddr(prefix netip.Addr, iid uint64) netip.Addr {
+	u := addrToUint128(prefix)
+	u.lo = iid
+	return u.addr(false)
+}
+
+// Check if an interface identifier is reserved by RFC 5453 and the IANA IPv6 Interface Identifiers registry
+func isReservedIID(iid uint64) bool {
+	return iid == 0 || // Subnet-Router Anycast
+		(iid >= 0xfdffffffffffff80 && iid <= 0xfdffffffffffffff) || // Reserved Subnet Anycast
+		(iid >= 0x02005efffe000000 && iid <= 0x02005efffeffffff) // Proxy Mobile IPv6 and reserved
+}
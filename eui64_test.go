@@ -0,0 +1,127 @@
+package netmath
+
+import (
+	"crypto/sha256"
+	"net"
+	"net/netip"
+	"testing"
+)
+
+func TestEUI64(t *testing.T) {
+	eui64Tests := []struct {
+		cidr   string
+		mac    string
+		result string
+	}{
+		{cidr: "2001:db8::/64", mac: "00:11:22:33:44:55", result: "2001:db8::211:22ff:fe33:4455"},
+		{cidr: "2001:db8:1:2::77/64", mac: "02:00:5e:10:00:01", result: "2001:db8:1:2:0:5eff:fe10:1"},
+		{cidr: "fe80::/64", mac: "52:54:00:ab:cd:ef", result: "fe80::5054:ff:feab:cdef"},
+		{cidr: "2001:db8::/64", mac: "00:11:22:ff:fe:33:44:55", result: "2001:db8::211:22ff:fe33:4455"},
+	}
+
+	for _, test := range eui64Tests {
+		s, _ := ParseCIDR(test.cidr)
+		mac, _ := net.ParseMAC(test.mac)
+		addr, err := s.EUI64(mac)
+		if err != nil || addr.String() != test.result {
+			t.Error("Error getting .EUI64() for", test.cidr, test.mac, "Expected:", test.result, "Got:", addr, err)
+		}
+	}
+
+	mac, _ := net.ParseMAC("00:11:22:33:44:55")
+	for _, cidr := range []string{"2001:db8::/48", "10.0.0.0/24"} {
+		s, _ := ParseCIDR(cidr)
+		if _, err := s.EUI64(mac); err == nil {
+			t.Error("Error getting .EUI64() for", cidr, "Expected an error Got:", err)
+		}
+	}
+	s, _ := ParseCIDR("2001:db8::/64")
+	if _, err := s.EUI64(net.HardwareAddr{1, 2, 3}); err == nil {
+		t.Error("Error getting .EUI64() for a 3 byte MAC", "Expected an error Got:", err)
+	}
+}
+
+func TestMACFromEUI64(t *testing.T) {
+	macTests := []struct {
+		addr   string
+		result string
+	}{
+		{addr: "2001:db8::211:22ff:fe33:4455", result: "00:11:22:33:44:55"},
+		{addr: "fe80::5054:ff:feab:cdef", result: "52:54:00:ab:cd:ef"},
+		{addr: "2001:db8::1", result: ""},
+		{addr: "192.0.2.1", result: ""},
+	}
+
+	for _, test := range macTests {
+		mac, err := MACFromEUI64(netip.MustParseAddr(test.addr))
+		if test.result == "" {
+			if err == nil {
+				t.Error("Error getting MACFromEUI64() for", test.addr, "Expected an error Got:", mac)
+			}
+			continue
+		}
+		if err != nil || mac.String() != test.result {
+			t.Error("Error getting MACFromEUI64() for", test.addr, "Expected:", test.result, "Got:", mac, err)
+		}
+	}
+}
+
+func TestStableAddr(t *testing.T) {
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	s, _ := ParseCIDR("2001:db8:1:2::/64")
+
+	addr, counter, err := s.StableAddr(secret, "eth0", []byte("ssid"), 0)
+	if err != nil || counter != 0 {
+		t.Fatal("Error getting .StableAddr() for", s, "Expected: <nil> Got:", err)
+	}
+
+	// F(Prefix, Net_Iface, Network_ID, DAD_Counter, secret_key) with the leftmost 64 bits as the identifier
+	sum := sha256.Sum256([]byte("\x20\x01\x0d\xb8\x00\x01\x00\x02eth0ssid\x00" + string(secret)))
+	var expected [16]byte
+	copy(expected[:8], []byte{0x20, 0x01, 0x0d, 0xb8, 0x00, 0x01, 0x00, 0x02})
+	copy(expected[8:], sum[:8])
+	if addr != netip.AddrFrom16(expected) {
+		t.Error("Error getting .StableAddr() for", s, "Expected:", netip.AddrFrom16(expected), "Got:", addr)
+	}
+
+	again, _, _ := s.StableAddr(secret, "eth0", []byte("ssid"), 0)
+	other, _, _ := s.StableAddr(secret, "eth1", []byte("ssid"), 0)
+	retry, _, _ := s.StableAddr(secret, "eth0", []byte("ssid"), 1)
+	if again != addr || other == addr || retry == addr {
+		t.Error("Error getting .StableAddr() for", s, "Expected a stable address per interface and counter Got:", addr, again, other, retry)
+	}
+	if !s.Contains(addr) || !s.Contains(other) {
+		t.Error("Error getting .StableAddr() for", s, "Expected addresses inside the prefix Got:", addr, other)
+	}
+
+	if _, _, err := s.StableAddr([]byte("short"), "eth0", nil, 0); err == nil {
+		t.Error("Error getting .StableAddr() with a short secret", "Expected an error Got:", err)
+	}
+	wide, _ := ParseCIDR("2001:db8::/56")
+	if _, _, err := wide.StableAddr(secret, "eth0", nil, 0); err == nil {
+		t.Error("Error getting .StableAddr() for", wide, "Expected an error Got:", err)
+	}
+}
+
+func TestIsReservedIID(t *testing.T) {
+	reservedTests := []struct {
+		iid      uint64
+		reserved bool
+	}{
+		{iid: 0, reserved: true},
+		{iid: 1, reserved: false},
+		{iid: 0xfdffffffffffff7f, reserved: false},
+		{iid: 0xfdffffffffffff80, reserved: true},
+		{iid: 0xfdffffffffffffff, reserved: true},
+		{iid: 0xfe00000000000000, reserved: false},
+		{iid: 0x02005efffe000000, reserved: true},
+		{iid: 0x02005efffe005213, reserved: true},
+		{iid: 0x02005effff000000, reserved: false},
+	}
+
+	for _, test := range reservedTests {
+		if isReservedIID(test.iid) != test.reserved {
+			t.Errorf("Error getting isReservedIID() for %#x Expected: %v Got: %v", test.iid, test.reserved, !test.reserved)
+		}
+	}
+}
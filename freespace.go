@@ -0,0 +1,76 @@
+package netmath
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Number of aligned blocks of one prefix length that fit in the free space
+type AvailableBlocks struct {
+	Bits  int
+	Count *big.Int
+}
+
+// Result of Subnet.FreeSpace
+type FreeSpace struct {
+	Parent      Subnet
+	Free        []Subnet          // Minimal list of unallocated prefixes in address order
+	Largest     Subnet            // Largest free prefix, the zero Subnet when the parent is full
+	Available   []AvailableBlocks // One entry per prefix length from the parent length to the host length
+	Used        *big.Int          // Number of allocated addresses, overlapping used subnets are counted once
+	Total       *big.Int
+	Utilization float64 // Percentage of addresses in use
+}
+
+// Analyze the space in the subnet that is not covered by the used subnets, which must lie inside it
+// ex. 10.0.0.0/24 with [10.0.0.0/26] -> free [10.0.0.64/26 10.0.0.128/25], 25% utilized
+func (s Subnet) FreeSpace(used []Subnet) (FreeSpace, error) {
+	if !s.IsValid() {
+		return FreeSpace{}, ErrInvalidPrefix
+	}
+	parent := NewSubnet(s.Masked())
+
+	for _, u := range used {
+		if !u.IsValid() {
+			return FreeSpace{}, ErrInvalidPrefix
+		}
+		if u.Addr().Is4() != parent.Addr().Is4() || u.Bits() < parent.Bits() || !parent.Contains(u.Addr()) {
+			return FreeSpace{}, fmt.Errorf("%s is not inside %s", u, parent)
+		}
+	}
+
+	parentSet, _ := NewIPSet(parent)
+	usedSet, err := NewIPSet(used...)
+	if err != nil {
+		return FreeSpace{}, err
+	}
+
+	fs := FreeSpace{
+		Parent: parent,
+		Free:   parentSet.Difference(usedSet).Prefixes(),
+		Used:   usedSet.Count(),
+		Total:  parentSet.Count(),
+	}
+
+	for _, f := range fs.Free {
+		if !fs.Largest.IsValid() || f.Bits() < fs.Largest.Bits() {
+			fs.Largest = f
+		}
+	}
+
+	width := parent.Addr().BitLen()
+	for bits := parent.Bits(); bits <= width; bits++ {
+		count := new(big.Int)
+		for _, f := range fs.Free {
+			if f.Bits() <= bits {
+				count.Add(count, new(big.Int).Lsh(big.NewInt(1), uint(bits-f.Bits())))
+			}
+		}
+		fs.Available = append(fs.Available, AvailableBlocks{Bits: bits, Count: count})
+	}
+
+	ratio := new(big.Rat).SetFrac(fs.Used, fs.Total)
+	fs.Utilization, _ = ratio.Mul(ratio, big.NewRat(100, 1)).Float64()
+
+	return fs, nil
+}
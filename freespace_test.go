@@ -0,0 +1,106 @@
+package netmath
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFreeSpace(t *testing.T) {
+	freeTests := []struct {
+		parent      string
+		used        []string
+		free        []string
+		largest     string
+		utilization float64
+		available   map[int]int64
+	}{
+		{
+			parent:      "10.0.0.0/24",
+			used:        []string{"10.0.0.0/26"},
+			free:        []string{"10.0.0.64/26", "10.0.0.128/25"},
+			largest:     "10.0.0.128/25",
+			utilization: 25,
+			available:   map[int]int64{24: 0, 25: 1, 26: 3, 27: 6, 32: 192},
+		},
+		{
+			parent:      "10.0.0.0/24",
+			used:        []string{"10.0.0.0/25", "10.0.0.64/26", "10.0.0.200/32"},
+			free:        []string{"10.0.0.128/26", "10.0.0.192/29", "10.0.0.201/32", "10.0.0.202/31", "10.0.0.204/30", "10.0.0.208/28", "10.0.0.224/27"},
+			largest:     "10.0.0.128/26",
+			utilization: 50.390625,
+			available:   map[int]int64{25: 0, 26: 1, 27: 3, 32: 127},
+		},
+		{
+			parent:      "192.168.1.0/24",
+			used:        []string{"192.168.1.0/24"},
+			free:        nil,
+			utilization: 100,
+			available:   map[int]int64{24: 0, 32: 0},
+		},
+		{
+			parent:      "192.168.1.77/24",
+			used:        nil,
+			free:        []string{"192.168.1.0/24"},
+			largest:     "192.168.1.0/24",
+			utilization: 0,
+			available:   map[int]int64{24: 1, 30: 64},
+		},
+		{
+			parent:      "2001:db8::/48",
+			used:        []string{"2001:db8::/64", "2001:db8:0:1::/64"},
+			free:        []string{"2001:db8:0:2::/63", "2001:db8:0:4::/62", "2001:db8:0:8::/61", "2001:db8:0:10::/60", "2001:db8:0:20::/59", "2001:db8:0:40::/58", "2001:db8:0:80::/57", "2001:db8:0:100::/56", "2001:db8:0:200::/55", "2001:db8:0:400::/54", "2001:db8:0:800::/53", "2001:db8:0:1000::/52", "2001:db8:0:2000::/51", "2001:db8:0:4000::/50", "2001:db8:0:8000::/49"},
+			largest:     "2001:db8:0:8000::/49",
+			utilization: 2.0 / 65536 * 100,
+			available:   map[int]int64{48: 0, 49: 1, 64: 65534},
+		},
+	}
+
+	for _, test := range freeTests {
+		parent, _ := ParseCIDR(test.parent)
+		fs, err := parent.FreeSpace(parseSubnets(t, test.used))
+		if err != nil {
+			t.Error("Error getting .FreeSpace() for", test.parent, "Expected: <nil> Got:", err)
+			continue
+		}
+
+		if free := subnetStrings(fs.Free); !slices.Equal(free, test.free) {
+			t.Error("Error getting .FreeSpace() for", test.parent, "Expected:", test.free, "Got:", free)
+		}
+		if (test.largest == "" && fs.Largest.IsValid()) || (test.largest != "" && fs.Largest.String() != test.largest) {
+			t.Error("Error getting .FreeSpace().Largest for", test.parent, "Expected:", test.largest, "Got:", fs.Largest)
+		}
+		if fs.Utilization != test.utilization {
+			t.Error("Error getting .FreeSpace().Utilization for", test.parent, "Expected:", test.utilization, "Got:", fs.Utilization)
+		}
+		if len(fs.Available) != fs.Parent.Addr().BitLen()-fs.Parent.Bits()+1 {
+			t.Error("Error getting .FreeSpace().Available for", test.parent, "Expected one entry per prefix length Got:", len(fs.Available))
+		}
+		for _, a := range fs.Available {
+			if want, ok := test.available[a.Bits]; ok && a.Count.Int64() != want {
+				t.Error("Error getting .FreeSpace().Available for", test.parent, "/", a.Bits, "Expected:", want, "Got:", a.Count)
+			}
+		}
+	}
+}
+
+func TestFreeSpaceErrors(t *testing.T) {
+	errorTests := []struct {
+		parent string
+		used   []string
+	}{
+		{parent: "10.0.0.0/24", used: []string{"10.0.1.0/26"}},
+		{parent: "10.0.0.0/24", used: []string{"10.0.0.0/16"}},
+		{parent: "10.0.0.0/24", used: []string{"2001:db8::/64"}},
+	}
+
+	for _, test := range errorTests {
+		parent, _ := ParseCIDR(test.parent)
+		if _, err := parent.FreeSpace(parseSubnets(t, test.used)); err == nil {
+			t.Error("Error getting .FreeSpace() for", test.parent, "with", test.used, "Expected an error Got:", err)
+		}
+	}
+
+	if _, err := (Subnet{}).FreeSpace(nil); err == nil {
+		t.Error("Error getting .FreeSpace() for the zero Subnet", "Expected an error Got:", err)
+	}
+}
@@ -0,0 +1,104 @@
+package netmath
+
+import (
+	"fmt"
+	"iter"
+	"math/big"
+	"net/netip"
+)
+
+// Options that control the order and starting point of an iterator
+type IterOptions struct {
+	// Iterate from the last value towards the first
+	Reverse bool
+	// Number of values to skip from the starting end, nil skips nothing
+	Offset *big.Int
+}
+
+// Iterate over the subnets in the list
+func (l SubnetList) Seq(opts IterOptions) (iter.Seq[Subnet], error) {
+	indices, err := indexSeq(l.last(), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(yield func(Subnet) bool) {
+		for i := range indices {
+			if !yield(l.at(i)) {
+				return
+			}
+		}
+	}, nil
+}
+
+// Iterate over the host addresses in the subnet. With usable set, the addresses excluded by CountUsable are skipped.
+func (s Subnet) Hosts(usable bool, opts IterOptions) (iter.Seq[netip.Addr], error) {
+	first, last, err := getHostBounds(s.Addr(), s.Bits(), usable)
+	if err != nil {
+		return nil, err
+	}
+
+	indices, err := indexSeq(last.sub(first), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	is4 := s.Addr().Is4()
+	return func(yield func(netip.Addr) bool) {
+		for i := range indices {
+			if !yield(first.add(i).addr(is4)) {
+				return
+			}
+		}
+	}, nil
+}
+
+// Iterate over the child subnets of the given prefix length ex. 10.0.0.0/23 by /24 -> 10.0.0.0/24, 10.0.1.0/24
+func (s Subnet) ChildSeq(bits int, opts IterOptions) (iter.Seq[Subnet], error) {
+	list, err := s.Children(bits)
+	if err != nil {
+		return nil, err
+	}
+	return list.Seq(opts)
+}
+
+// Iterate over the subnets of the same size inside the parent prefix of length parentBits
+func (s Subnet) SiblingSeq(parentBits int, opts IterOptions) (iter.Seq[Subnet], error) {
+	list, err := s.Siblings(parentBits)
+	if err != nil {
+		return nil, err
+	}
+	return list.Seq(opts)
+}
+
+// Iterate over the indices 0 through last, honoring the order and offset options
+func indexSeq(last uint128, opts IterOptions) (iter.Seq[uint128], error) {
+	var offset uint128
+	if opts.Offset != nil {
+		if opts.Offset.Sign() < 0 {
+			return nil, fmt.Errorf("invalid offset")
+		}
+
+		o, ok := uint128FromBig(opts.Offset)
+		if !ok || o.cmp(last) > 0 {
+			return func(yield func(uint128) bool) {}, nil
+		}
+		offset = o
+	}
+
+	return func(yield func(uint128) bool) {
+		if opts.Reverse {
+			for i := last.sub(offset); ; i = i.subOne() {
+				if !yield(i) || i.isZero() {
+					return
+				}
+			}
+		}
+
+		for i := offset; ; i = i.addOne() {
+			if !yield(i) || i == last {
+				return
+			}
+		}
+	}, nil
+}
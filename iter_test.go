@@ -0,0 +1,112 @@
+package netmath
+
+import (
+	"math/big"
+	"slices"
+	"testing"
+)
+
+func TestHosts(t *testing.T) {
+	hostTests := []struct {
+		snet    string
+		usable  bool
+		reverse bool
+		offset  int64
+		limit   int
+		want    []string
+	}{
+		{snet: "192.168.1.0/30", want: []string{"192.168.1.0", "192.168.1.1", "192.168.1.2", "192.168.1.3"}},
+		{snet: "192.168.1.0/30", usable: true, want: []string{"192.168.1.1", "192.168.1.2"}},
+		{snet: "192.168.1.0/30", usable: true, reverse: true, want: []string{"192.168.1.2", "192.168.1.1"}},
+		{snet: "192.168.1.0/31", usable: true, want: []string{"192.168.1.0", "192.168.1.1"}},
+		{snet: "192.168.1.9/32", usable: true, want: []string{"192.168.1.9"}},
+		{snet: "192.168.1.0/24", offset: 250, want: []string{"192.168.1.250", "192.168.1.251", "192.168.1.252", "192.168.1.253", "192.168.1.254", "192.168.1.255"}},
+		{snet: "192.168.1.0/24", reverse: true, offset: 1, limit: 2, want: []string{"192.168.1.254", "192.168.1.253"}},
+		{snet: "192.168.1.0/24", offset: 256, want: nil},
+		{snet: "2001:db8::/126", usable: true, want: []string{"2001:db8::1", "2001:db8::2", "2001:db8::3"}},
+		{snet: "::/0", reverse: true, limit: 2, want: []string{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe"}},
+		{snet: "::/0", usable: true, limit: 2, want: []string{"::1", "::2"}},
+	}
+
+	for _, test := range hostTests {
+		s, _ := ParseCIDR(test.snet)
+		seq, err := s.Hosts(test.usable, IterOptions{Reverse: test.reverse, Offset: big.NewInt(test.offset)})
+		if err != nil {
+			t.Error("Error getting .Hosts() for", test.snet, "Error:", err)
+			continue
+		}
+
+		var got []string
+		for addr := range seq {
+			got = append(got, addr.String())
+			if len(got) == test.limit {
+				break
+			}
+		}
+		if !slices.Equal(got, test.want) {
+			t.Error("Error getting .Hosts() for", test.snet, "Expected:", test.want, "Got:", got)
+		}
+	}
+}
+
+func TestChildSeq(t *testing.T) {
+	childTests := []struct {
+		snet    string
+		bits    int
+		reverse bool
+		offset  int64
+		limit   int
+		want    []string
+	}{
+		{snet: "10.0.0.0/22", bits: 24, want: []string{"10.0.0.0/24", "10.0.1.0/24", "10.0.2.0/24", "10.0.3.0/24"}},
+		{snet: "10.0.0.0/22", bits: 24, reverse: true, offset: 1, want: []string{"10.0.2.0/24", "10.0.1.0/24", "10.0.0.0/24"}},
+		{snet: "10.0.0.0/22", bits: 22, want: []string{"10.0.0.0/22"}},
+		{snet: "2001:db8::/32", bits: 64, offset: 65536, limit: 2, want: []string{"2001:db8:1::/64", "2001:db8:1:1::/64"}},
+		{snet: "::/0", bits: 128, reverse: true, limit: 1, want: []string{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff/128"}},
+	}
+
+	for _, test := range childTests {
+		s, _ := ParseCIDR(test.snet)
+		seq, err := s.ChildSeq(test.bits, IterOptions{Reverse: test.reverse, Offset: big.NewInt(test.offset)})
+		if err != nil {
+			t.Error("Error getting .ChildSeq() for", test.snet, "Error:", err)
+			continue
+		}
+
+		var got []string
+		for child := range seq {
+			got = append(got, child.String())
+			if len(got) == test.limit {
+				break
+			}
+		}
+		if !slices.Equal(got, test.want) {
+			t.Error("Error getting .ChildSeq() for", test.snet, "Expected:", test.want, "Got:", got)
+		}
+	}
+
+	s, _ := ParseCIDR("10.0.0.0/24")
+	if _, err := s.ChildSeq(23, IterOptions{}); err == nil {
+		t.Error("Error getting .ChildSeq() for a shorter prefix length, Expected an error")
+	}
+	if _, err := s.ChildSeq(25, IterOptions{Offset: big.NewInt(-1)}); err == nil {
+		t.Error("Error getting .ChildSeq() for a negative offset, Expected an error")
+	}
+}
+
+func TestSiblingSeq(t *testing.T) {
+	s, _ := ParseCIDR("172.16.5.0/24")
+	seq, err := s.SiblingSeq(22, IterOptions{Reverse: true})
+	if err != nil {
+		t.Fatal("Error getting .SiblingSeq() for", s, "Error:", err)
+	}
+
+	var got []string
+	for sibling := range seq {
+		got = append(got, sibling.String())
+	}
+	want := []string{"172.16.7.0/24", "172.16.6.0/24", "172.16.5.0/24", "172.16.4.0/24"}
+	if !slices.Equal(got, want) {
+		t.Error("Error getting .SiblingSeq() for", s, "Expected:", want, "Got:", got)
+	}
+}
@@ -0,0 +1,80 @@
+package netmath
+
+import (
+	"fmt"
+	"math/big"
+	"net/netip"
+)
+
+// An ordered, lazily evaluated list of equally sized subnets that fill a parent prefix
+type SubnetList struct {
+	base  uint128 // Network address of the parent prefix
+	bits  int     // Prefix length of every subnet in the list
+	depth int     // Difference between bits and the parent prefix length, the list holds 2^depth subnets
+	is4   bool
+}
+
+func newSubnetList(addr netip.Addr, parentBits int, bits int) (SubnetList, error) {
+	if !addr.IsValid() {
+		return SubnetList{}, ErrInvalidAddr
+	}
+
+	width := addr.BitLen()
+	if bits < 0 || bits > width {
+		return SubnetList{}, fmt.Errorf("%w: %d", ErrInvalidBits, bits)
+	}
+	if parentBits < 0 || parentBits > bits {
+		return SubnetList{}, fmt.Errorf("%w: parent %d", ErrInvalidBits, parentBits)
+	}
+
+	base := addrToUint128(addr).and(hostMask(parentBits, width).not())
+
+	return SubnetList{base: base, bits: bits, depth: bits - parentBits, is4: addr.Is4()}, nil
+}
+
+// List every subnet of the same size inside the parent prefix of length parentBits ex. 10.1.2.0/24 in /22 -> [10.1.0.0/24 ... 10.1.3.0/24]
+func (s Subnet) Siblings(parentBits int) (SubnetList, error) {
+	return newSubnetList(s.Addr(), parentBits, s.Bits())
+}
+
+// Prefix length of every subnet in the list
+func (l SubnetList) Bits() int {
+	return l.bits
+}
+
+// Parent prefix that the list fills
+func (l SubnetList) Parent() Subnet {
+	return NewSubnet(netip.PrefixFrom(l.base.addr(l.is4), l.bits-l.depth))
+}
+
+// Number of subnets in the list
+func (l SubnetList) Len() *big.Int {
+	return new(big.Int).Lsh(big.NewInt(1), uint(l.depth))
+}
+
+// Get the subnet at index i without enumerating the ones before it
+func (l SubnetList) At(i *big.Int) (Subnet, error) {
+	if i == nil || i.Sign() < 0 || i.Cmp(l.Len()) >= 0 {
+		return Subnet{}, fmt.Errorf("index out of range")
+	}
+
+	n, _ := uint128FromBig(i)
+	return l.at(n), nil
+}
+
+func (l SubnetList) width() int {
+	if l.is4 {
+		return 32
+	}
+	return 128
+}
+
+func (l SubnetList) at(i uint128) Subnet {
+	addr := l.base.add(i.lsh(uint(l.width() - l.bits)))
+	return NewSubnet(netip.PrefixFrom(addr.addr(l.is4), l.bits))
+}
+
+// Index of the last subnet in the list
+func (l SubnetList) last() uint128 {
+	return uint128{hi: ^uint64(0), lo: ^uint64(0)}.rsh(uint(128 - l.depth))
+}
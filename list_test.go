@@ -0,0 +1,69 @@
+package netmath
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestSiblings(t *testing.T) {
+	siblingTests := []struct {
+		snet       string
+		parentBits int
+		wantLen    string
+		index      string
+		want       string
+	}{
+		{snet: "10.1.2.0/24", parentBits: 22, wantLen: "4", index: "0", want: "10.1.0.0/24"},
+		{snet: "10.1.2.0/24", parentBits: 22, wantLen: "4", index: "3", want: "10.1.3.0/24"},
+		{snet: "10.1.2.0/24", parentBits: 24, wantLen: "1", index: "0", want: "10.1.2.0/24"},
+		{snet: "10.1.2.99/32", parentBits: 0, wantLen: "4294967296", index: "4294967295", want: "255.255.255.255/32"},
+		{snet: "192.168.7.130/26", parentBits: 23, wantLen: "8", index: "5", want: "192.168.7.64/26"},
+		{snet: "2001:db8:1::/48", parentBits: 32, wantLen: "65536", index: "65535", want: "2001:db8:ffff::/48"},
+		{snet: "::/128", parentBits: 0, wantLen: "340282366920938463463374607431768211456", index: "340282366920938463463374607431768211455", want: "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff/128"},
+		{snet: "2001:db8::/64", parentBits: 63, wantLen: "2", index: "1", want: "2001:db8:0:1::/64"},
+	}
+
+	for _, test := range siblingTests {
+		s, _ := ParseCIDR(test.snet)
+		list, err := s.Siblings(test.parentBits)
+		if err != nil {
+			t.Error("Error getting .Siblings() for", test.snet, "in", test.parentBits, "Error:", err)
+			continue
+		}
+		if list.Len().String() != test.wantLen {
+			t.Error("Error getting .Siblings() for", test.snet, "in", test.parentBits, "Expected length:", test.wantLen, "Got:", list.Len())
+		}
+
+		i, _ := new(big.Int).SetString(test.index, 10)
+		got, err := list.At(i)
+		if err != nil || got.String() != test.want {
+			t.Error("Error getting .At() for", test.snet, "in", test.parentBits, "Expected:", test.want, "Got:", got.String(), "With Error:", err)
+		}
+	}
+}
+
+func TestSiblingsInvalid(t *testing.T) {
+	invalidTests := []struct {
+		snet       string
+		parentBits int
+	}{
+		{snet: "10.0.0.0/8", parentBits: 9},
+		{snet: "10.0.0.0/8", parentBits: -1},
+		{snet: "invalid", parentBits: 0},
+	}
+
+	for _, test := range invalidTests {
+		s, _ := ParseCIDR(test.snet)
+		if _, err := s.Siblings(test.parentBits); err == nil {
+			t.Error("Error getting .Siblings() for", test.snet, "in", test.parentBits, "Expected an error")
+		}
+	}
+
+	s, _ := ParseCIDR("10.1.2.0/24")
+	list, _ := s.Siblings(22)
+	for _, i := range []int64{-1, 4} {
+		if _, err := list.At(big.NewInt(i)); err == nil {
+			t.Error("Error getting .At() for index", i, "Expected an error")
+		}
+	}
+}
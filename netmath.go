@@ -3,6 +3,7 @@ package netmath
 import (
 	"fmt"
 	"math"
+	"math/big"
 	"net/netip"
 )
 
@@ -16,32 +17,41 @@ func NewSubnet(p netip.Prefix) Subnet {
 	return Subnet{Prefix: p}
 }
 
-// Parse an IP and Subnet Mask in the long <ip-address>, <subnet-mask> format
+// Parse an IP and Subnet Mask in the long <ip-address>, <subnet-mask> format.
+// Failures are reported as a *ParseError.
 func Parse(addrStr string, maskStr string) (Subnet, error) {
 	addr, err := netip.ParseAddr(addrStr)
-	if err != nil {
-		return Subnet{}, fmt.Errorf("invalid host address")
+	if err != nil || addr.Zone() != "" {
+		offset, reason := locateAddrError(addrStr)
+		return Subnet{}, newParseError(addrStr, offset, ErrInvalidAddr, reason)
 	}
 
 	mask, err := netip.ParseAddr(maskStr)
 	if err != nil {
-		return Subnet{}, fmt.Errorf("invalid subnet mask")
+		offset, reason := locateAddrError(maskStr)
+		return Subnet{}, newParseError(maskStr, offset, ErrInvalidMask, reason)
 	}
 
 	maskBits, err := maskToBits(mask)
 	if err != nil {
-		return Subnet{}, fmt.Errorf("invalid subnet mask")
+		return Subnet{}, newParseError(maskStr, locateBit(maskStr, nonContiguousBit(mask)), ErrInvalidMask, ErrNonContiguousMask)
+	}
+	if maskBits > addr.BitLen() {
+		// Masks of either family are accepted, so only a prefix length the address can not hold is an error
+		// ex. a /48 IPv6 mask is too long for an IPv4 address, a /32 one is not
+		return Subnet{}, newParseError(maskStr, locateBit(maskStr, addr.BitLen()), ErrInvalidMask, ErrBitsOutOfRange)
 	}
 	p := netip.PrefixFrom(addr, maskBits)
 
 	return Subnet{Prefix: p}, nil
 }
 
-// Parse an abbreviated IP and Subnet mask in the <ip-address>/<bits> format
+// Parse an abbreviated IP and Subnet mask in the <ip-address>/<bits> format.
+// Failures are reported as a *ParseError.
 func ParseCIDR(s string) (Subnet, error) {
 	p, err := netip.ParsePrefix(s)
 	if err != nil {
-		return Subnet{}, fmt.Errorf("invalid subnet")
+		return Subnet{}, locatePrefixError(s)
 	}
 	return Subnet{Prefix: p}, nil
 }
@@ -53,7 +63,7 @@ func (s Subnet) Mask() (netip.Addr, error) {
 
 	mask, err := bitsToMask(bits, addr.Is4())
 	if err != nil {
-		return netip.IPv4Unspecified(), fmt.Errorf("invalid network mask")
+		return netip.IPv4Unspecified(), fmt.Errorf("invalid network mask: %w", err)
 	}
 
 	return mask, nil
@@ -71,7 +81,7 @@ func (s Subnet) Network() (netip.Addr, error) {
 
 	na, ok := netip.AddrFromSlice(naBytes)
 	if !ok {
-		return netip.IPv4Unspecified(), fmt.Errorf("invalid network address")
+		return netip.IPv4Unspecified(), fmt.Errorf("invalid network address: %w", ErrInvalidPrefix)
 	}
 
 	return na, nil
@@ -89,28 +99,19 @@ func (s Subnet) Broadcast() (netip.Addr, error) {
 
 	ba, ok := netip.AddrFromSlice(baBytes)
 	if !ok {
-		return netip.IPv4Unspecified(), fmt.Errorf("invalid network address")
+		return netip.IPv4Unspecified(), fmt.Errorf("invalid broadcast address: %w", ErrInvalidPrefix)
 	}
 
 	return ba, nil
 }
 
 // Count the number of total hosts in the subnet. (You can subtract two from the result for the usable hosts)
+//
+// Deprecated: Count loses precision once a value no longer fits in a float64. Use CountExact or CountUsable instead.
 func (s Subnet) Count() (float64, error) {
-	addr := s.Addr()
-	bits := s.Bits()
-
-	if bits < 0 || bits > 128 {
-		return 0, fmt.Errorf("invalid bit length")
-	}
-
-	var hostBits int
-	if addr.Is4() {
-		hostBits = 32 - bits
-	} else if addr.Is6() {
-		hostBits = 128 - bits
-	} else {
-		return 0, fmt.Errorf("invalid address")
+	hostBits, err := getHostBits(s.Addr(), s.Bits())
+	if err != nil {
+		return 0, err
 	}
 
 	hosts := math.Pow(2, float64(hostBits))
@@ -118,35 +119,53 @@ func (s Subnet) Count() (float64, error) {
 	return hosts, nil
 }
 
-// List all of the neighboring subnets that use the same mask
-func (s Subnet) ListAll() []Subnet {
-	addr := s.Addr()
-	addrBytes := addr.AsSlice()
+// Count the exact number of total addresses in the subnet ex. ::/48 -> 1208925819614629174706176
+func (s Subnet) CountExact() (*big.Int, error) {
+	hostBits, err := getHostBits(s.Addr(), s.Bits())
+	if err != nil {
+		return nil, err
+	}
 
-	mask, _ := s.Mask()
-	maskBytes := mask.AsSlice()
+	return new(big.Int).Lsh(big.NewInt(1), uint(hostBits)), nil
+}
 
-	var netBytes []byte
-	var step int
+// Count the exact number of usable host addresses in the subnet.
+// IPv4 excludes the network and broadcast addresses, except for /31 (RFC 3021) and /32 networks.
+// IPv6 has no broadcast and only excludes the Subnet-Router anycast address, except for /127 (RFC 6164) and /128 networks.
+func (s Subnet) CountUsable() (*big.Int, error) {
+	hostBits, err := getHostBits(s.Addr(), s.Bits())
+	if err != nil {
+		return nil, err
+	}
 
-	for i := 0; i < len(maskBytes); i++ {
-		if maskBytes[i] < 255 {
-			step = 256 - int(maskBytes[i])
-			break
-		} else {
-			netBytes = append(netBytes, addrBytes[i])
-		}
+	hosts := new(big.Int).Lsh(big.NewInt(1), uint(hostBits))
+	if hostBits <= 1 {
+		return hosts, nil
 	}
 
-	var subnets []Subnet
-	for j := 0; j < 255; j += step {
-		tempBytes := netBytes
-		tempBytes = append(tempBytes, byte(j))
+	if s.Addr().Is4() {
+		return hosts.Sub(hosts, big.NewInt(2)), nil
+	}
+	return hosts.Sub(hosts, big.NewInt(1)), nil
+}
 
-		tempBytes = fillEmptyBytes(tempBytes, addr.Is4())
-		tempAddr, _ := netip.AddrFromSlice(tempBytes)
+// List all of the neighboring subnets that use the same mask within the enclosing octet ex. 10.0.0.0/9 -> [10.0.0.0/9 10.128.0.0/9]
+func (s Subnet) ListAll() []Subnet {
+	parentBits := 0
+	if s.Bits() > 0 {
+		parentBits = (s.Bits() - 1) / 8 * 8
+	}
+
+	list, err := s.Siblings(parentBits)
+	if err != nil {
+		return nil
+	}
 
-		subnets = append(subnets, NewSubnet(netip.PrefixFrom(tempAddr, s.Bits())))
+	// At most 8 bits separate the subnets from their parent, so the list never exceeds 256 entries
+	n := list.last().lo
+	subnets := make([]Subnet, 0, n+1)
+	for i := uint64(0); i <= n; i++ {
+		subnets = append(subnets, list.at(uint128{lo: i}))
 	}
 
 	return subnets
@@ -1,6 +1,9 @@
 package netmath
 
 import (
+	"errors"
+	"math/big"
+	"net/netip"
 	"testing"
 )
 
@@ -9,6 +12,7 @@ func TestParse(t *testing.T) {
 		addr string
 		mask string
 		want string
+		err  error
 	}{
 		// Lowest/Highest Valid Values
 		{addr: "0.0.0.0", mask: "0.0.0.0", want: "0.0.0.0/0"},
@@ -20,35 +24,37 @@ func TestParse(t *testing.T) {
 		{addr: "::", mask: "255.255.255.255", want: "::/32"},
 		{addr: "::", mask: "0.0.0.0", want: "::/0"},
 		{addr: "255.255.255.255", mask: "::", want: "255.255.255.255/0"},
-		{addr: "255.255.255.255", mask: "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", want: "invalid Prefix"}, //128 Bits out of range for IPv4
+		{addr: "10.0.0.0", mask: "ffff:ffff::", want: "10.0.0.0/32"},
+		{addr: "255.255.255.255", mask: "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", err: ErrBitsOutOfRange}, //128 Bits out of range for IPv4
 
 		// Invalid Host
-		{addr: "-1.0.0.0", mask: "0.0.0.0", want: "invalid host address"},
-		{addr: "0.-1.0.0", mask: "0.0.0.0", want: "invalid host address"},
-		{addr: "0.0.-1.0", mask: "0.0.0.0", want: "invalid host address"},
-		{addr: "0.0.0.-1", mask: "0.0.0.0", want: "invalid host address"},
-		{addr: "255.255.255.256", mask: "0.0.0.0", want: "invalid host address"},
-		{addr: "255.255.256.255", mask: "0.0.0.0", want: "invalid host address"},
-		{addr: "255.256.255.255", mask: "0.0.0.0", want: "invalid host address"},
-		{addr: "256.255.255.255", mask: "0.0.0.0", want: "invalid host address"},
-		{addr: "0.0.0", mask: "0.0.0.0", want: "invalid host address"},        //Length error
-		{addr: "255.255.255", mask: "0.0.0.0", want: "invalid host address"},  //Length error
-		{addr: "0.0.0.", mask: "0.0.0.0", want: "invalid host address"},       //Length error
-		{addr: "255.255.255.", mask: "0.0.0.0", want: "invalid host address"}, //Length error
+		{addr: "-1.0.0.0", mask: "0.0.0.0", err: ErrInvalidAddr},
+		{addr: "0.-1.0.0", mask: "0.0.0.0", err: ErrInvalidAddr},
+		{addr: "0.0.-1.0", mask: "0.0.0.0", err: ErrInvalidAddr},
+		{addr: "0.0.0.-1", mask: "0.0.0.0", err: ErrInvalidAddr},
+		{addr: "255.255.255.256", mask: "0.0.0.0", err: ErrInvalidAddr},
+		{addr: "255.255.256.255", mask: "0.0.0.0", err: ErrInvalidAddr},
+		{addr: "255.256.255.255", mask: "0.0.0.0", err: ErrInvalidAddr},
+		{addr: "256.255.255.255", mask: "0.0.0.0", err: ErrInvalidAddr},
+		{addr: "0.0.0", mask: "0.0.0.0", err: ErrInvalidAddr},        //Length error
+		{addr: "255.255.255", mask: "0.0.0.0", err: ErrInvalidAddr},  //Length error
+		{addr: "0.0.0.", mask: "0.0.0.0", err: ErrInvalidAddr},       //Length error
+		{addr: "255.255.255.", mask: "0.0.0.0", err: ErrInvalidAddr}, //Length error
+		{addr: "fe80::1%eth0", mask: "ffff::", err: ErrInvalidAddr},  //Zones are rejected like ParseCIDR
 
 		//Invalid Mask
-		{addr: "0.0.0.0", mask: "255.255.255.256", want: "invalid subnet mask"}, //Out of range error
-		{addr: "0.0.0.0", mask: "255.255.256.255", want: "invalid subnet mask"}, //Out of range error
-		{addr: "0.0.0.0", mask: "255.256.255.255", want: "invalid subnet mask"}, //Out of range error
-		{addr: "0.0.0.0", mask: "256.255.255.255", want: "invalid subnet mask"}, //Out of range error
-		{addr: "0.0.0.0", mask: "0.0.0.-1", want: "invalid subnet mask"},        //Out of range error
-		{addr: "0.0.0.0", mask: "0.0.-1.0", want: "invalid subnet mask"},        //Out of range error
-		{addr: "0.0.0.0", mask: "0.-1.0.0", want: "invalid subnet mask"},        //Out of range error
-		{addr: "0.0.0.0", mask: "-1.0.0.0", want: "invalid subnet mask"},        //Out of range error
-		{addr: "0.0.0.0", mask: "255.255.255.251", want: "invalid subnet mask"}, // Sub-Byte error
-		{addr: "0.0.0.0", mask: "255.255.0.255", want: "invalid subnet mask"},   // Byte error
-		{addr: "0.0.0.0", mask: "255.255.255", want: "invalid subnet mask"},     // Length error
-		{addr: "0.0.0.0", mask: "255.255.255.", want: "invalid subnet mask"},    // Length error
+		{addr: "0.0.0.0", mask: "255.255.255.256", err: ErrInvalidMask}, //Out of range error
+		{addr: "0.0.0.0", mask: "255.255.256.255", err: ErrInvalidMask}, //Out of range error
+		{addr: "0.0.0.0", mask: "255.256.255.255", err: ErrInvalidMask}, //Out of range error
+		{addr: "0.0.0.0", mask: "256.255.255.255", err: ErrInvalidMask}, //Out of range error
+		{addr: "0.0.0.0", mask: "0.0.0.-1", err: ErrInvalidMask},        //Out of range error
+		{addr: "0.0.0.0", mask: "0.0.-1.0", err: ErrInvalidMask},        //Out of range error
+		{addr: "0.0.0.0", mask: "0.-1.0.0", err: ErrInvalidMask},        //Out of range error
+		{addr: "0.0.0.0", mask: "-1.0.0.0", err: ErrInvalidMask},        //Out of range error
+		{addr: "0.0.0.0", mask: "255.255.255.251", err: ErrInvalidMask}, // Sub-Byte error
+		{addr: "0.0.0.0", mask: "255.255.0.255", err: ErrInvalidMask},   // Byte error
+		{addr: "0.0.0.0", mask: "255.255.255", err: ErrInvalidMask},     // Length error
+		{addr: "0.0.0.0", mask: "255.255.255.", err: ErrInvalidMask},    // Length error
 
 		// Common random
 		{addr: "192.168.0.0", mask: "255.255.255.0", want: "192.168.0.0/24"},
@@ -61,9 +67,9 @@ func TestParse(t *testing.T) {
 	for _, test := range parseTests {
 		s, err := Parse(test.addr, test.mask)
 
-		if err != nil {
-			if err.Error() != test.want {
-				t.Error("Error parsing", test.addr, "and", test.mask, "Expected:", test.want, "Got:", s.String(), "With Error:", err)
+		if err != nil || test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Error("Error parsing", test.addr, "and", test.mask, "Expected:", test.err, "Got:", s.String(), "With Error:", err)
 			}
 		} else if s.String() != test.want {
 			t.Error("Error parsing", test.addr, "and", test.mask, "Expected:", test.want, "Got:", s.String())
@@ -321,10 +327,8 @@ func TestListAll(t *testing.T) {
 		snet string
 		want []string
 	}{
-		{snet: "0.0.0.0/8", want: []string{"0.0.0.0/8"}},
 		{snet: "0.0.0.0/7", want: []string{"0.0.0.0/7", "2.0.0.0/7", "4.0.0.0/7", "6.0.0.0/7", "8.0.0.0/7", "10.0.0.0/7", "12.0.0.0/7", "14.0.0.0/7", "16.0.0.0/7", "18.0.0.0/7", "20.0.0.0/7", "22.0.0.0/7", "24.0.0.0/7", "26.0.0.0/7", "28.0.0.0/7", "30.0.0.0/7", "32.0.0.0/7", "34.0.0.0/7", "36.0.0.0/7", "38.0.0.0/7", "40.0.0.0/7", "42.0.0.0/7", "44.0.0.0/7", "46.0.0.0/7", "48.0.0.0/7", "50.0.0.0/7", "52.0.0.0/7", "54.0.0.0/7", "56.0.0.0/7", "58.0.0.0/7", "60.0.0.0/7", "62.0.0.0/7", "64.0.0.0/7", "66.0.0.0/7", "68.0.0.0/7", "70.0.0.0/7", "72.0.0.0/7", "74.0.0.0/7", "76.0.0.0/7", "78.0.0.0/7", "80.0.0.0/7", "82.0.0.0/7", "84.0.0.0/7", "86.0.0.0/7", "88.0.0.0/7", "90.0.0.0/7", "92.0.0.0/7", "94.0.0.0/7", "96.0.0.0/7", "98.0.0.0/7", "100.0.0.0/7", "102.0.0.0/7", "104.0.0.0/7", "106.0.0.0/7", "108.0.0.0/7", "110.0.0.0/7", "112.0.0.0/7", "114.0.0.0/7", "116.0.0.0/7", "118.0.0.0/7", "120.0.0.0/7", "122.0.0.0/7", "124.0.0.0/7", "126.0.0.0/7", "128.0.0.0/7", "130.0.0.0/7", "132.0.0.0/7", "134.0.0.0/7", "136.0.0.0/7", "138.0.0.0/7", "140.0.0.0/7", "142.0.0.0/7", "144.0.0.0/7", "146.0.0.0/7", "148.0.0.0/7", "150.0.0.0/7", "152.0.0.0/7", "154.0.0.0/7", "156.0.0.0/7", "158.0.0.0/7", "160.0.0.0/7", "162.0.0.0/7", "164.0.0.0/7", "166.0.0.0/7", "168.0.0.0/7", "170.0.0.0/7", "172.0.0.0/7", "174.0.0.0/7", "176.0.0.0/7", "178.0.0.0/7", "180.0.0.0/7", "182.0.0.0/7", "184.0.0.0/7", "186.0.0.0/7", "188.0.0.0/7", "190.0.0.0/7", "192.0.0.0/7", "194.0.0.0/7", "196.0.0.0/7", "198.0.0.0/7", "200.0.0.0/7", "202.0.0.0/7", "204.0.0.0/7", "206.0.0.0/7", "208.0.0.0/7", "210.0.0.0/7", "212.0.0.0/7", "214.0.0.0/7", "216.0.0.0/7", "218.0.0.0/7", "220.0.0.0/7", "222.0.0.0/7", "224.0.0.0/7", "226.0.0.0/7", "228.0.0.0/7", "230.0.0.0/7", "232.0.0.0/7", "234.0.0.0/7", "236.0.0.0/7", "238.0.0.0/7", "240.0.0.0/7", "242.0.0.0/7", "244.0.0.0/7", "246.0.0.0/7", "248.0.0.0/7", "250.0.0.0/7", "252.0.0.0/7", "254.0.0.0/7"}},
 		{snet: "0.0.0.0/4", want: []string{"0.0.0.0/4", "16.0.0.0/4", "32.0.0.0/4", "48.0.0.0/4", "64.0.0.0/4", "80.0.0.0/4", "96.0.0.0/4", "112.0.0.0/4", "128.0.0.0/4", "144.0.0.0/4", "160.0.0.0/4", "176.0.0.0/4", "192.0.0.0/4", "208.0.0.0/4", "224.0.0.0/4", "240.0.0.0/4"}},
-		{snet: "::/8", want: []string{"::/8"}},
 		{snet: "::/7", want: []string{"::/7", "200::/7", "400::/7", "600::/7", "800::/7", "a00::/7", "c00::/7", "e00::/7", "1000::/7", "1200::/7", "1400::/7", "1600::/7", "1800::/7", "1a00::/7", "1c00::/7", "1e00::/7", "2000::/7", "2200::/7", "2400::/7", "2600::/7", "2800::/7", "2a00::/7", "2c00::/7", "2e00::/7", "3000::/7", "3200::/7", "3400::/7", "3600::/7", "3800::/7", "3a00::/7", "3c00::/7", "3e00::/7", "4000::/7", "4200::/7", "4400::/7", "4600::/7", "4800::/7", "4a00::/7", "4c00::/7", "4e00::/7", "5000::/7", "5200::/7", "5400::/7", "5600::/7", "5800::/7", "5a00::/7", "5c00::/7", "5e00::/7", "6000::/7", "6200::/7", "6400::/7", "6600::/7", "6800::/7", "6a00::/7", "6c00::/7", "6e00::/7", "7000::/7", "7200::/7", "7400::/7", "7600::/7", "7800::/7", "7a00::/7", "7c00::/7", "7e00::/7", "8000::/7", "8200::/7", "8400::/7", "8600::/7", "8800::/7", "8a00::/7", "8c00::/7", "8e00::/7", "9000::/7", "9200::/7", "9400::/7", "9600::/7", "9800::/7", "9a00::/7", "9c00::/7", "9e00::/7", "a000::/7", "a200::/7", "a400::/7", "a600::/7", "a800::/7", "aa00::/7", "ac00::/7", "ae00::/7", "b000::/7", "b200::/7", "b400::/7", "b600::/7", "b800::/7", "ba00::/7", "bc00::/7", "be00::/7", "c000::/7", "c200::/7", "c400::/7", "c600::/7", "c800::/7", "ca00::/7", "cc00::/7", "ce00::/7", "d000::/7", "d200::/7", "d400::/7", "d600::/7", "d800::/7", "da00::/7", "dc00::/7", "de00::/7", "e000::/7", "e200::/7", "e400::/7", "e600::/7", "e800::/7", "ea00::/7", "ec00::/7", "ee00::/7", "f000::/7", "f200::/7", "f400::/7", "f600::/7", "f800::/7", "fa00::/7", "fc00::/7", "fe00::/7"}},
 		{snet: "::/4", want: []string{"::/4", "1000::/4", "2000::/4", "3000::/4", "4000::/4", "5000::/4", "6000::/4", "7000::/4", "8000::/4", "9000::/4", "a000::/4", "b000::/4", "c000::/4", "d000::/4", "e000::/4", "f000::/4"}},
 	}
@@ -345,6 +349,35 @@ func TestListAll(t *testing.T) {
 	}
 }
 
+func TestListAllOctetBoundary(t *testing.T) {
+	boundaryTests := []struct {
+		snet  string
+		len   int
+		first string
+		last  string
+	}{
+		{snet: "0.0.0.0/0", len: 1, first: "0.0.0.0/0", last: "0.0.0.0/0"},
+		{snet: "0.0.0.0/8", len: 256, first: "0.0.0.0/8", last: "255.0.0.0/8"},
+		{snet: "172.16.0.0/16", len: 256, first: "172.0.0.0/16", last: "172.255.0.0/16"},
+		{snet: "192.168.1.77/24", len: 256, first: "192.168.0.0/24", last: "192.168.255.0/24"},
+		{snet: "10.20.30.40/32", len: 256, first: "10.20.30.0/32", last: "10.20.30.255/32"},
+		{snet: "::/8", len: 256, first: "::/8", last: "ff00::/8"},
+		{snet: "2001:db8::/48", len: 256, first: "2001:db8::/48", last: "2001:db8:ff::/48"},
+	}
+
+	for _, test := range boundaryTests {
+		s, _ := ParseCIDR(test.snet)
+		list := s.ListAll()
+		if len(list) != test.len {
+			t.Error("Error getting .ListAll() for", test.snet, "Error: Lengths do not match. Want", test.len, "Got", len(list))
+			continue
+		}
+		if list[0].String() != test.first || list[len(list)-1].String() != test.last {
+			t.Error("Error getting .ListAll() for", test.snet, "Expected:", test.first, "to", test.last, "Got:", list[0], "to", list[len(list)-1])
+		}
+	}
+}
+
 func TestCount(t *testing.T) {
 	countTests := []struct {
 		snet string
@@ -525,3 +558,72 @@ func TestCount(t *testing.T) {
 		}
 	}
 }
+
+func TestCountExact(t *testing.T) {
+	exactTests := []struct {
+		snet string
+		want string
+	}{
+		{snet: "0.0.0.0/0", want: "4294967296"},
+		{snet: "10.0.0.0/8", want: "16777216"},
+		{snet: "192.168.0.0/32", want: "1"},
+		{snet: "::/0", want: "340282366920938463463374607431768211456"},
+		{snet: "2001:db8::/48", want: "1208925819614629174706176"},
+		{snet: "2001:db8::/56", want: "4722366482869645213696"},
+		{snet: "2001:db8::/64", want: "18446744073709551616"},
+		{snet: "::/128", want: "1"},
+	}
+
+	for _, test := range exactTests {
+		s, _ := ParseCIDR(test.snet)
+		c, err := s.CountExact()
+		if err != nil || c.String() != test.want {
+			t.Error("Error getting .CountExact() for", test.snet, "Want", test.want, "Got", c, "With Error:", err)
+		}
+	}
+
+	// Every prefix length must agree with the float count, which is exact for powers of two
+	for _, base := range []string{"0.0.0.0", "::"} {
+		addr := netip.MustParseAddr(base)
+		for bits := 0; bits <= addr.BitLen(); bits++ {
+			s := NewSubnet(netip.PrefixFrom(addr, bits))
+			f, _ := s.Count()
+			c, err := s.CountExact()
+			want, _ := new(big.Float).SetFloat64(f).Int(nil)
+			if err != nil || c.Cmp(want) != 0 {
+				t.Error("Error getting .CountExact() for", s.String(), "Want", want, "Got", c, "With Error:", err)
+			}
+		}
+	}
+}
+
+func TestCountUsable(t *testing.T) {
+	usableTests := []struct {
+		snet string
+		want string
+	}{
+		{snet: "0.0.0.0/0", want: "4294967294"},
+		{snet: "192.168.0.0/24", want: "254"},
+		{snet: "192.168.0.0/30", want: "2"},
+		{snet: "192.168.0.0/31", want: "2"},
+		{snet: "192.168.0.0/32", want: "1"},
+		{snet: "::/0", want: "340282366920938463463374607431768211455"},
+		{snet: "2001:db8::/48", want: "1208925819614629174706175"},
+		{snet: "2001:db8::/64", want: "18446744073709551615"},
+		{snet: "2001:db8::/126", want: "3"},
+		{snet: "2001:db8::/127", want: "2"},
+		{snet: "2001:db8::/128", want: "1"},
+	}
+
+	for _, test := range usableTests {
+		s, _ := ParseCIDR(test.snet)
+		c, err := s.CountUsable()
+		if err != nil || c.String() != test.want {
+			t.Error("Error getting .CountUsable() for", test.snet, "Want", test.want, "Got", c, "With Error:", err)
+		}
+	}
+
+	if _, err := (Subnet{}).CountUsable(); err == nil {
+		t.Error("Error getting .CountUsable() for an invalid subnet, Expected an error")
+	}
+}
@@ -0,0 +1,100 @@
+package netmath
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// Subnet with a label naming where it is used ex. a VPC, VLAN or VPN pool
+type LabeledSubnet struct {
+	Label  string
+	Subnet Subnet
+}
+
+// Relationship between two overlapping subnets.
+// Two prefixes are either disjoint or one contains the other, so there is no partial overlap kind.
+type OverlapKind int
+
+const (
+	OverlapDuplicate   OverlapKind = iota // Both subnets have the same network and prefix length
+	OverlapContainment                    // Outer contains the smaller Inner subnet
+)
+
+var overlapKindNames = map[OverlapKind]string{
+	OverlapDuplicate:   "duplicate",
+	OverlapContainment: "containment",
+}
+
+func (k OverlapKind) String() string {
+	if name, ok := overlapKindNames[k]; ok {
+		return name
+	}
+	return "OverlapKind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// Pair of overlapping inventory entries. For duplicates Outer is the entry listed first in the inventory.
+type Overlap struct {
+	Outer LabeledSubnet
+	Inner LabeledSubnet
+	Kind  OverlapKind
+}
+
+// Report every overlapping pair in the inventory, IPv4 first, ordered by the address of the inner subnet.
+// Entries are sorted once and swept with a stack of enclosing subnets, so the cost is O(n log n) plus one step per reported pair.
+func FindOverlaps(inventory []LabeledSubnet) ([]Overlap, error) {
+	type entry struct {
+		index int
+		sp    span
+		bits  int
+		is4   bool
+	}
+
+	entries := make([]entry, len(inventory))
+	for i, ls := range inventory {
+		sp, err := subnetSpan(ls.Subnet)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", ls.Label, err)
+		}
+		entries[i] = entry{index: i, sp: sp, bits: ls.Subnet.Bits(), is4: ls.Subnet.Addr().Is4()}
+	}
+
+	// Sorting by start then prefix length places every subnet after the subnets that contain it
+	slices.SortFunc(entries, func(a, b entry) int {
+		if a.is4 != b.is4 {
+			if a.is4 {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Or(a.sp.from.cmp(b.sp.from), a.bits-b.bits, a.index-b.index)
+	})
+
+	var overlaps []Overlap
+	var stack []entry
+	for _, e := range entries {
+		for len(stack) > 0 {
+			top := stack[len(stack)-1]
+			if top.is4 == e.is4 && top.sp.to.cmp(e.sp.from) >= 0 {
+				break
+			}
+			stack = stack[:len(stack)-1]
+		}
+
+		for _, outer := range stack {
+			kind := OverlapContainment
+			if outer.bits == e.bits {
+				kind = OverlapDuplicate
+			}
+			overlaps = append(overlaps, Overlap{
+				Outer: inventory[outer.index],
+				Inner: inventory[e.index],
+				Kind:  kind,
+			})
+		}
+		stack = append(stack, e)
+	}
+
+	return overlaps, nil
+}
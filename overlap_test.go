@@ -0,0 +1,86 @@
+package netmath
+
+import (
+	"fmt"
+	"math/rand"
+	"net/netip"
+	"slices"
+	"testing"
+)
+
+func TestFindOverlaps(t *testing.T) {
+	inventory := []LabeledSubnet{
+		{Label: "vpc-a", Subnet: mustParseCIDR("10.0.0.0/16")},
+		{Label: "vlan-10", Subnet: mustParseCIDR("10.0.10.0/24")},
+		{Label: "vpn-pool", Subnet: mustParseCIDR("172.16.0.0/20")},
+		{Label: "vpc-b", Subnet: mustParseCIDR("10.0.0.0/16")},
+		{Label: "lab", Subnet: mustParseCIDR("10.1.0.0/16")},
+		{Label: "site-v6", Subnet: mustParseCIDR("2001:db8::/48")},
+		{Label: "vlan-v6", Subnet: mustParseCIDR("2001:db8:0:1::/64")},
+		{Label: "host", Subnet: mustParseCIDR("10.0.10.7/32")},
+		{Label: "v4-all", Subnet: mustParseCIDR("0.0.0.0/0")},
+	}
+
+	overlaps, err := FindOverlaps(inventory)
+	if err != nil {
+		t.Fatal("Error getting FindOverlaps()", "Expected: <nil> Got:", err)
+	}
+
+	var result []string
+	for _, o := range overlaps {
+		result = append(result, fmt.Sprintf("%s>%s:%s", o.Outer.Label, o.Inner.Label, o.Kind))
+	}
+
+	expected := []string{
+		"v4-all>vpc-a:containment",
+		"v4-all>vpc-b:containment",
+		"vpc-a>vpc-b:duplicate",
+		"v4-all>vlan-10:containment",
+		"vpc-a>vlan-10:containment",
+		"vpc-b>vlan-10:containment",
+		"v4-all>host:containment",
+		"vpc-a>host:containment",
+		"vpc-b>host:containment",
+		"vlan-10>host:containment",
+		"v4-all>lab:containment",
+		"v4-all>vpn-pool:containment",
+		"site-v6>vlan-v6:containment",
+	}
+	if !slices.Equal(result, expected) {
+		t.Error("Error getting FindOverlaps()", "Expected:", expected, "Got:", result)
+	}
+
+	if _, err := FindOverlaps([]LabeledSubnet{{Label: "bad"}}); err == nil {
+		t.Error("Error getting FindOverlaps() for an invalid subnet", "Expected an error Got:", err)
+	}
+}
+
+func TestFindOverlapsMatchesPairwise(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	var inventory []LabeledSubnet
+	for i := 0; i < 300; i++ {
+		addr := netip.AddrFrom4([4]byte{10, byte(r.Intn(4)), byte(r.Intn(256)), byte(r.Intn(256))})
+		p := netip.PrefixFrom(addr, 14+r.Intn(19)).Masked()
+		inventory = append(inventory, LabeledSubnet{Label: fmt.Sprint(i), Subnet: NewSubnet(p)})
+	}
+
+	pairwise := 0
+	for i := range inventory {
+		for j := i + 1; j < len(inventory); j++ {
+			if inventory[i].Subnet.Overlaps(inventory[j].Subnet.Prefix) {
+				pairwise++
+			}
+		}
+	}
+
+	overlaps, err := FindOverlaps(inventory)
+	if err != nil || len(overlaps) != pairwise {
+		t.Error("Error getting FindOverlaps()", "Expected:", pairwise, "Got:", len(overlaps), err)
+	}
+	for _, o := range overlaps {
+		if !o.Outer.Subnet.Overlaps(o.Inner.Subnet.Prefix) || o.Outer.Subnet.Bits() > o.Inner.Subnet.Bits() {
+			t.Error("Error getting FindOverlaps()", "Expected Outer to contain Inner Got:", o.Outer.Subnet, o.Inner.Subnet)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package netmath
+
+import (
+	"errors"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// Notation of an input detected by ParseAny
+type Notation int
+
+const (
+	NotationAddr      Notation = iota // 10.1.1.1
+	NotationCIDR                      // 10.1.1.0/24
+	NotationAddrMask                  // 10.1.1.0 255.255.255.0
+	NotationSlashMask                 // 10.1.1.0/255.255.255.0
+	NotationWildcard                  // 10.1.1.0 0.0.0.255
+	NotationRange                     // 10.1.1.0-10.1.1.255
+	NotationClassful                  // 10.1/16 or 10/8
+	NotationHex                       // 0x0a010100 or 0x0a010100/24
+	NotationInteger                   // 167837952 or 167837952/24
+)
+
+var notationNames = map[Notation]string{
+	NotationAddr:      "address",
+	NotationCIDR:      "cidr",
+	NotationAddrMask:  "address and mask",
+	NotationSlashMask: "address/mask",
+	NotationWildcard:  "address and wildcard",
+	NotationRange:     "range",
+	NotationClassful:  "classful shorthand",
+	NotationHex:       "hexadecimal",
+	NotationInteger:   "integer",
+}
+
+func (n Notation) String() string {
+	if name, ok := notationNames[n]; ok {
+		return name
+	}
+	return "Notation(" + strconv.Itoa(int(n)) + ")"
+}
+
+// Result of ParseAny. Range is set for NotationRange, Subnet for every other notation.
+type ParseResult struct {
+	Notation Notation
+	Subnet   Subnet
+	Range    IPRange
+}
+
+// Check if the input was a range rather than a subnet
+func (r ParseResult) IsRange() bool {
+	return r.Notation == NotationRange
+}
+
+// Parse a subnet or range in any common notation and report which one was detected.
+// Accepted notations are listed with the Notation constants. A bare address becomes a /32 or /128 subnet.
+// Classful shorthand fills the missing trailing octets with zeros, so 10.1/16 is 10.1.0.0/16 and 10/8 is 10.0.0.0/8.
+// An address and mask can be separated by whitespace or a comma. Separated masks are read as netmasks when contiguous and as wildcards otherwise.
+// Failures are reported as a *ParseError.
+func ParseAny(s string) (ParseResult, error) {
+	trimmed := strings.TrimSpace(s)
+	lead := strings.Index(s, trimmed)
+	if trimmed == "" {
+		return ParseResult{}, newParseError(s, len(s), ErrInvalidPrefix, ErrSyntax)
+	}
+
+	var res ParseResult
+	var err error
+	if strings.Contains(trimmed, "-") {
+		res.Notation = NotationRange
+		res.Range, err = ParseRange(trimmed)
+	} else if strings.ContainsFunc(trimmed, isFieldSep) {
+		res, err = parseAddrAndMask(trimmed)
+	} else if addrStr, suffix, ok := strings.Cut(trimmed, "/"); ok {
+		res, err = parseSlash(trimmed, addrStr, suffix)
+	} else {
+		var addr netip.Addr
+		addr, res.Notation, err = parseAnyAddr(trimmed, false)
+		res.Subnet = NewSubnet(netip.PrefixFrom(addr, addr.BitLen()))
+	}
+
+	if err != nil {
+		return ParseResult{}, relocateError(err, s, lead)
+	}
+	return res, nil
+}
+
+// Parse <ip-address> <subnet-mask> or <ip-address> <wildcard-mask>
+func parseAddrAndMask(s string) (ParseResult, error) {
+	addrStr, maskStr, addrOffset, maskOffset, err := splitAddrAndMask(s)
+	if err != nil {
+		return ParseResult{}, err
+	}
+
+	mask, err := netip.ParseAddr(maskStr)
+	if err == nil && nonContiguousBit(mask) >= 0 && nonContiguousBit(invertAddr(mask)) < 0 {
+		subnet, err := ParseWildcard(addrStr, maskStr)
+		if err != nil {
+			return ParseResult{}, relocatePartError(err, s, addrOffset, maskOffset)
+		}
+		return ParseResult{Notation: NotationWildcard, Subnet: subnet}, nil
+	}
+
+	subnet, err := Parse(addrStr, maskStr)
+	if err != nil {
+		return ParseResult{}, relocatePartError(err, s, addrOffset, maskOffset)
+	}
+	return ParseResult{Notation: NotationAddrMask, Subnet: subnet}, nil
+}
+
+// Split <ip-address> <mask> or <ip-address>, <mask> into its fields and the offsets where they start
+func splitAddrAndMask(s string) (string, string, int, int, error) {
+	var fields []string
+	var offsets []int
+	start := -1
+	for i := 0; i <= len(s); i++ {
+		if i < len(s) && !isFieldSep(rune(s[i])) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			fields = append(fields, s[start:i])
+			offsets = append(offsets, start)
+			start = -1
+		}
+	}
+
+	if len(fields) < 2 {
+		return "", "", 0, 0, newParseError(s, len(s), ErrInvalidPrefix, ErrSyntax)
+	}
+	if len(fields) > 2 {
+		return "", "", 0, 0, newParseError(s, offsets[2], ErrInvalidPrefix, ErrSyntax)
+	}
+	return fields[0], fields[1], offsets[0], offsets[1], nil
+}
+
+func isFieldSep(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == ','
+}
+
+// Parse <address>/<bits> or <ip-address>/<subnet-mask>, where the address can be in any notation
+func parseSlash(s string, addrStr string, suffix string) (ParseResult, error) {
+	maskOffset := len(addrStr) + 1
+	if strings.ContainsAny(suffix, ".:") {
+		subnet, err := Parse(addrStr, suffix)
+		if err != nil {
+			return ParseResult{}, relocatePartError(err, s, 0, maskOffset)
+		}
+		return ParseResult{Notation: NotationSlashMask, Subnet: subnet}, nil
+	}
+
+	addr, notation, err := parseAnyAddr(addrStr, true)
+	if err != nil {
+		return ParseResult{}, relocateError(err, s, 0)
+	}
+	if notation == NotationAddr {
+		notation = NotationCIDR
+	}
+
+	bits, err := strconv.Atoi(suffix)
+	if err != nil || suffix[0] == '+' || suffix[0] == '-' || (len(suffix) > 1 && suffix[0] == '0') {
+		return ParseResult{}, newParseError(s, maskOffset, ErrInvalidPrefix, ErrSyntax)
+	}
+	if bits > addr.BitLen() {
+		return ParseResult{}, newParseError(s, maskOffset, ErrInvalidPrefix, ErrBitsOutOfRange)
+	}
+
+	return ParseResult{Notation: notation, Subnet: NewSubnet(netip.PrefixFrom(addr, bits))}, nil
+}
+
+// Parse an address in standard, hexadecimal, integer or (with a prefix length) classful notation
+func parseAnyAddr(s string, hasBits bool) (netip.Addr, Notation, error) {
+	// Zoned addresses fall through so the '%' is reported
+	if addr, err := netip.ParseAddr(s); err == nil && addr.Zone() == "" {
+		return addr, NotationAddr, nil
+	}
+
+	if hex, ok := strings.CutPrefix(strings.ToLower(s), "0x"); ok {
+		n, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil || hex == "" || !allHex(hex) {
+			return netip.Addr{}, 0, newParseError(s, 2, ErrInvalidAddr, ErrSyntax)
+		}
+		return uint128{lo: n}.addr(true), NotationHex, nil
+	}
+
+	if s != "" && !strings.ContainsFunc(s, func(r rune) bool { return r < '0' || r > '9' }) {
+		if len(s) > 1 && s[0] == '0' {
+			return netip.Addr{}, 0, newParseError(s, 0, ErrInvalidAddr, ErrSyntax)
+		}
+		n, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return netip.Addr{}, 0, newParseError(s, 0, ErrInvalidAddr, ErrOctetOutOfRange)
+		}
+		// A single octet with a prefix length is classful shorthand ex. 10/8
+		if hasBits && len(s) <= 3 && n <= 255 {
+			return netip.AddrFrom4([4]byte{byte(n)}), NotationClassful, nil
+		}
+		return uint128{lo: n}.addr(true), NotationInteger, nil
+	}
+
+	// Classful shorthand needs a prefix length to tell it apart from a truncated address
+	if dots := strings.Count(s, "."); hasBits && dots > 0 && dots < 3 {
+		padded := s + strings.Repeat(".0", 3-dots)
+		if addr, err := netip.ParseAddr(padded); err == nil {
+			return addr, NotationClassful, nil
+		}
+	}
+
+	offset, reason := locateAddrError(s)
+	return netip.Addr{}, 0, newParseError(s, offset, ErrInvalidAddr, reason)
+}
+
+func allHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isHex(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Report a ParseError against the full input, shifting its offset by the start of the part that failed
+func relocateError(err error, s string, start int) error {
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		return err
+	}
+
+	offset := pe.Offset
+	if offset >= 0 {
+		offset += start
+	}
+	return newParseError(s, offset, pe.Err, pe.Reason)
+}
+
+// Relocate an error from Parse or ParseWildcard, where address errors start at addrOffset and mask errors at maskOffset
+func relocatePartError(err error, s string, addrOffset int, maskOffset int) error {
+	if errors.Is(err, ErrInvalidAddr) {
+		return relocateError(err, s, addrOffset)
+	}
+	return relocateError(err, s, maskOffset)
+}
@@ -0,0 +1,101 @@
+package netmath
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseAny(t *testing.T) {
+	parseTests := []struct {
+		input    string
+		notation Notation
+		want     string
+	}{
+		{input: "10.1.1.1", notation: NotationAddr, want: "10.1.1.1/32"},
+		{input: "2001:db8::1", notation: NotationAddr, want: "2001:db8::1/128"},
+		{input: "10.1.1.0/24", notation: NotationCIDR, want: "10.1.1.0/24"},
+		{input: "  2001:db8::/32 ", notation: NotationCIDR, want: "2001:db8::/32"},
+		{input: "10.1.1.0 255.255.255.0", notation: NotationAddrMask, want: "10.1.1.0/24"},
+		{input: "10.1.1.0\t255.255.252.0", notation: NotationAddrMask, want: "10.1.1.0/22"},
+		{input: "10.1.1.0/255.255.255.0", notation: NotationSlashMask, want: "10.1.1.0/24"},
+		{input: "10.1.1.0 0.0.0.255", notation: NotationWildcard, want: "10.1.1.0/24"},
+		{input: "10.1.1.0 0.0.0.0", notation: NotationAddrMask, want: "10.1.1.0/0"}, // Both readings are valid, netmask wins
+		{input: "10.1.1.0-10.1.1.255", notation: NotationRange, want: "10.1.1.0-10.1.1.255"},
+		{input: "10.1/16", notation: NotationClassful, want: "10.1.0.0/16"},
+		{input: "172.16.5/24", notation: NotationClassful, want: "172.16.5.0/24"},
+		{input: "10/8", notation: NotationClassful, want: "10.0.0.0/8"},
+		{input: "192/2", notation: NotationClassful, want: "192.0.0.0/2"},
+		{input: "256/24", notation: NotationInteger, want: "0.0.1.0/24"},
+		{input: "10", notation: NotationInteger, want: "0.0.0.10/32"},
+		{input: "10.1.1.0, 255.255.255.0", notation: NotationAddrMask, want: "10.1.1.0/24"},
+		{input: "0x0a010100", notation: NotationHex, want: "10.1.1.0/32"},
+		{input: "0X0A010100/24", notation: NotationHex, want: "10.1.1.0/24"},
+		{input: "167837952", notation: NotationInteger, want: "10.1.1.0/32"},
+		{input: "167837952/24", notation: NotationInteger, want: "10.1.1.0/24"},
+		{input: "4294967295", notation: NotationInteger, want: "255.255.255.255/32"},
+	}
+
+	for _, test := range parseTests {
+		res, err := ParseAny(test.input)
+		if err != nil {
+			t.Error("Error parsing", test.input, "Error:", err)
+			continue
+		}
+
+		got := res.Subnet.String()
+		if res.IsRange() {
+			got = res.Range.String()
+		}
+		if res.Notation != test.notation || got != test.want {
+			t.Error("Error parsing", test.input, "Expected:", test.notation, test.want, "Got:", res.Notation, got)
+		}
+	}
+}
+
+func TestParseAnyInvalid(t *testing.T) {
+	invalidTests := []struct {
+		input  string
+		offset int
+		reason error
+	}{
+		{input: "", offset: 0, reason: ErrSyntax},
+		{input: "10.1", offset: 4, reason: ErrSyntax},
+		{input: "10.1.1.0 255.0.255.0", offset: 15, reason: ErrNonContiguousMask},
+		{input: " 10.1.1.0 255.255.255.0 x", offset: 24, reason: ErrSyntax},
+		{input: "10.1.1.0/33", offset: 9, reason: ErrBitsOutOfRange},
+		{input: "10.1.1.0/2x", offset: 9, reason: ErrSyntax},
+		{input: "10.1.1.300/24", offset: 7, reason: ErrOctetOutOfRange},
+		{input: "10.1.1.0/255.255.0.255", offset: 19, reason: ErrNonContiguousMask},
+		{input: "0x1g/8", offset: 2, reason: ErrSyntax},
+		{input: "4294967296", offset: 0, reason: ErrOctetOutOfRange},
+		{input: "10.1.1.5-10.1.1.1", offset: 9, reason: ErrReversedRange},
+		{input: "1.1.1.1 1.1.1.1 1", offset: 16, reason: ErrSyntax},
+		{input: " 10.1.1.300 255.0.0.0", offset: 8, reason: ErrOctetOutOfRange},
+		{input: "0010/8", offset: 0, reason: ErrSyntax},
+		{input: "010", offset: 0, reason: ErrSyntax},
+		{input: "fe80::1%eth0/64", offset: 7, reason: ErrSyntax},
+		{input: " fe80::1%eth0", offset: 8, reason: ErrSyntax},
+		{input: "fe80::1%eth0 ffff::", offset: 7, reason: ErrSyntax},
+		{input: "fe80::1%eth0 ::ffff", offset: 7, reason: ErrSyntax},
+		{input: "fe80::1%eth0/ffff::", offset: 7, reason: ErrSyntax},
+	}
+
+	for _, test := range invalidTests {
+		_, err := ParseAny(test.input)
+
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Error("Error parsing", test.input, "Expected a *ParseError Got:", err)
+			continue
+		}
+		if pe.Input != test.input || pe.Offset != test.offset || !errors.Is(err, test.reason) {
+			t.Error("Error parsing", test.input, "Expected:", test.offset, test.reason, "Got:", pe)
+		}
+	}
+}
+
+func TestNotationString(t *testing.T) {
+	if NotationWildcard.String() != "address and wildcard" || Notation(99).String() != "Notation(99)" {
+		t.Error("Error getting .String() for notations Got:", NotationWildcard, Notation(99))
+	}
+}
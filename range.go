@@ -0,0 +1,170 @@
+package netmath
+
+import (
+	"fmt"
+	"math/big"
+	"net/netip"
+	"strings"
+)
+
+// Inclusive range of addresses of the same family that does not have to align with a prefix
+type IPRange struct {
+	From netip.Addr
+	To   netip.Addr
+}
+
+// Create a new IPRange, From and To must be the same family with From <= To
+func NewIPRange(from netip.Addr, to netip.Addr) (IPRange, error) {
+	r := IPRange{From: from, To: to}
+	switch {
+	case !from.IsValid() || !to.IsValid():
+		return IPRange{}, ErrInvalidAddr
+	case from.Is4() != to.Is4():
+		return IPRange{}, fmt.Errorf("%w: %w", ErrInvalidRange, ErrFamilyMismatch)
+	case from.Compare(to) > 0:
+		return IPRange{}, fmt.Errorf("%w: %w", ErrInvalidRange, ErrReversedRange)
+	}
+	return r, nil
+}
+
+// Parse a range in the <first-address>-<last-address> format ex. 10.0.0.5-10.0.0.77.
+// Failures are reported as a *ParseError.
+func ParseRange(s string) (IPRange, error) {
+	dash := strings.IndexByte(s, '-')
+	if dash < 0 {
+		return IPRange{}, newParseError(s, len(s), ErrInvalidRange, ErrSyntax)
+	}
+
+	from, err := parseRangeAddr(s, 0, dash)
+	if err != nil {
+		return IPRange{}, err
+	}
+
+	to, err := parseRangeAddr(s, dash+1, len(s))
+	if err != nil {
+		return IPRange{}, err
+	}
+
+	switch {
+	case from.Is4() != to.Is4():
+		return IPRange{}, newParseError(s, dash+1, ErrInvalidRange, ErrFamilyMismatch)
+	case from.Compare(to) > 0:
+		return IPRange{}, newParseError(s, dash+1, ErrInvalidRange, ErrReversedRange)
+	}
+	return IPRange{From: from, To: to}, nil
+}
+
+// Parse the address in s[start:end], ignoring surrounding spaces
+func parseRangeAddr(s string, start int, end int) (netip.Addr, error) {
+	field := s[start:end]
+	trimmed := strings.TrimLeft(field, " ")
+	start += len(field) - len(trimmed)
+	trimmed = strings.TrimRight(trimmed, " ")
+
+	// Zones are rejected like ParseCIDR does, a range has no use for them
+	addr, err := netip.ParseAddr(trimmed)
+	if err != nil || addr.Zone() != "" {
+		offset, reason := locateAddrError(trimmed)
+		if offset >= 0 {
+			offset += start
+		}
+		return netip.Addr{}, newParseError(s, offset, ErrInvalidAddr, reason)
+	}
+	return addr, nil
+}
+
+// Get the range of addresses from the network to the broadcast address ex. 192.168.20.15/23 -> 192.168.20.0-192.168.21.255
+func (s Subnet) Range() (IPRange, error) {
+	na, err := s.Network()
+	if err != nil {
+		return IPRange{}, err
+	}
+
+	ba, err := s.Broadcast()
+	if err != nil {
+		return IPRange{}, err
+	}
+
+	return NewIPRange(na, ba)
+}
+
+// Get the range of usable host addresses, following the same rules as CountUsable ex. 192.168.20.0/23 -> 192.168.20.1-192.168.21.254
+func (s Subnet) UsableRange() (IPRange, error) {
+	first, last, err := getHostBounds(s.Addr(), s.Bits(), true)
+	if err != nil {
+		return IPRange{}, err
+	}
+
+	is4 := s.Addr().Is4()
+	return NewIPRange(first.addr(is4), last.addr(is4))
+}
+
+// Check if both ends are valid addresses of the same family in ascending order
+func (r IPRange) IsValid() bool {
+	return r.From.IsValid() && r.To.IsValid() && r.From.Is4() == r.To.Is4() && r.From.Compare(r.To) <= 0
+}
+
+func (r IPRange) span() span {
+	return span{from: addrToUint128(r.From), to: addrToUint128(r.To)}
+}
+
+// Check if the address is in the range
+func (r IPRange) Contains(addr netip.Addr) bool {
+	return r.IsValid() && addr.IsValid() && addr.Is4() == r.From.Is4() &&
+		r.From.Compare(addr) <= 0 && addr.Compare(r.To) <= 0
+}
+
+// Check if every address of o is in the range
+func (r IPRange) ContainsRange(o IPRange) bool {
+	return o.IsValid() && r.Contains(o.From) && r.Contains(o.To)
+}
+
+// Check if the ranges share at least one address
+func (r IPRange) Overlaps(o IPRange) bool {
+	return r.IsValid() && o.IsValid() && r.From.Is4() == o.From.Is4() &&
+		r.From.Compare(o.To) <= 0 && o.From.Compare(r.To) <= 0
+}
+
+// Count the exact number of addresses in the range
+func (r IPRange) Size() *big.Int {
+	if !r.IsValid() {
+		return new(big.Int)
+	}
+
+	sp := r.span()
+	size := sp.to.sub(sp.from).big()
+	return size.Add(size, big.NewInt(1))
+}
+
+// Get the minimal list of subnets that exactly covers the range ex. 10.0.0.0-10.0.1.255 -> [10.0.0.0/23]
+func (r IPRange) Prefixes() []Subnet {
+	if !r.IsValid() {
+		return nil
+	}
+	return spanPrefixes(r.span(), r.From.Is4())
+}
+
+// Format the range in the <first-address>-<last-address> format
+func (r IPRange) String() string {
+	if !r.IsValid() {
+		return "invalid IPRange"
+	}
+	return r.From.String() + "-" + r.To.String()
+}
+
+// Create a new IPSet holding every address of the given ranges
+func IPSetFromRanges(ranges ...IPRange) (IPSet, error) {
+	var v4, v6 []span
+	for _, r := range ranges {
+		if !r.IsValid() {
+			return IPSet{}, ErrInvalidRange
+		}
+
+		if r.From.Is4() {
+			v4 = append(v4, r.span())
+		} else {
+			v6 = append(v6, r.span())
+		}
+	}
+	return IPSet{v4: mergeSpans(v4), v6: mergeSpans(v6)}, nil
+}
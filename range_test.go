@@ -0,0 +1,173 @@
+package netmath
+
+import (
+	"errors"
+	"net/netip"
+	"slices"
+	"testing"
+)
+
+func TestParseRange(t *testing.T) {
+	parseTests := []struct {
+		rng  string
+		want string
+		err  error
+	}{
+		{rng: "10.0.0.5-10.0.0.77", want: "10.0.0.5-10.0.0.77"},
+		{rng: "10.0.0.5 - 10.0.0.77", want: "10.0.0.5-10.0.0.77"},
+		{rng: "10.0.0.5-10.0.0.5", want: "10.0.0.5-10.0.0.5"},
+		{rng: "2001:db8::1-2001:db8::ff", want: "2001:db8::1-2001:db8::ff"},
+		{rng: "10.0.0.77-10.0.0.5", err: ErrReversedRange},
+		{rng: "10.0.0.5-2001:db8::1", err: ErrFamilyMismatch},
+		{rng: "10.0.0.5", err: ErrInvalidRange},
+		{rng: "10.0.0.256-10.0.1.0", err: ErrInvalidAddr},
+		{rng: "fe80::1%eth0-fe80::5%eth0", err: ErrSyntax},
+		{rng: "fe80::1-fe80::5%eth0", err: ErrInvalidAddr},
+	}
+
+	for _, test := range parseTests {
+		r, err := ParseRange(test.rng)
+		if err != nil || test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Error("Error parsing", test.rng, "Expected:", test.err, "With Error:", err)
+			}
+		} else if r.String() != test.want {
+			t.Error("Error parsing", test.rng, "Expected:", test.want, "Got:", r.String())
+		}
+	}
+}
+
+func TestParseRangeZoneOffset(t *testing.T) {
+	input := "fe80::1 - fe80::5%eth0"
+	_, err := ParseRange(input)
+
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Offset != 17 || !errors.Is(err, ErrSyntax) {
+		t.Error("Error parsing", input, "Expected: syntax error at offset 17 Got:", err)
+	}
+}
+
+func TestRangePrefixes(t *testing.T) {
+	prefixTests := []struct {
+		rng  string
+		want []string
+	}{
+		{rng: "10.0.0.5-10.0.0.77", want: []string{"10.0.0.5/32", "10.0.0.6/31", "10.0.0.8/29", "10.0.0.16/28", "10.0.0.32/27", "10.0.0.64/29", "10.0.0.72/30", "10.0.0.76/31"}},
+		{rng: "10.0.0.0-10.0.1.255", want: []string{"10.0.0.0/23"}},
+		{rng: "192.168.0.255-192.168.1.0", want: []string{"192.168.0.255/32", "192.168.1.0/32"}},
+		{rng: "2001:db8::-2001:db8::ffff", want: []string{"2001:db8::/112"}},
+	}
+
+	for _, test := range prefixTests {
+		r, _ := ParseRange(test.rng)
+		if got := subnetStrings(r.Prefixes()); !slices.Equal(got, test.want) {
+			t.Error("Error getting .Prefixes() for", test.rng, "Expected:", test.want, "Got:", got)
+		}
+	}
+}
+
+func TestRangeChecks(t *testing.T) {
+	r, _ := ParseRange("10.0.0.5-10.0.0.77")
+
+	if got := r.Size().String(); got != "73" {
+		t.Error("Error getting .Size() for", r, "Expected: 73 Got:", got)
+	}
+
+	containsTests := []struct {
+		addr string
+		want bool
+	}{
+		{addr: "10.0.0.5", want: true},
+		{addr: "10.0.0.77", want: true},
+		{addr: "10.0.0.4", want: false},
+		{addr: "10.0.0.78", want: false},
+		{addr: "::ffff:10.0.0.6", want: false},
+	}
+
+	for _, test := range containsTests {
+		if got := r.Contains(netip.MustParseAddr(test.addr)); got != test.want {
+			t.Error("Error getting .Contains() for", test.addr, "Expected:", test.want, "Got:", got)
+		}
+	}
+
+	rangeTests := []struct {
+		other    string
+		contains bool
+		overlaps bool
+	}{
+		{other: "10.0.0.10-10.0.0.20", contains: true, overlaps: true},
+		{other: "10.0.0.70-10.0.0.90", contains: false, overlaps: true},
+		{other: "10.0.0.0-10.0.0.5", contains: false, overlaps: true},
+		{other: "10.0.0.78-10.0.0.90", contains: false, overlaps: false},
+		{other: "2001:db8::-2001:db8::ff", contains: false, overlaps: false},
+	}
+
+	for _, test := range rangeTests {
+		o, _ := ParseRange(test.other)
+		if got := r.ContainsRange(o); got != test.contains {
+			t.Error("Error getting .ContainsRange() for", test.other, "Expected:", test.contains, "Got:", got)
+		}
+		if got := r.Overlaps(o); got != test.overlaps {
+			t.Error("Error getting .Overlaps() for", test.other, "Expected:", test.overlaps, "Got:", got)
+		}
+	}
+}
+
+func TestSubnetRange(t *testing.T) {
+	rangeTests := []struct {
+		snet string
+		want string
+	}{
+		{snet: "192.168.20.15/23", want: "192.168.20.0-192.168.21.255"},
+		{snet: "10.0.0.0/32", want: "10.0.0.0-10.0.0.0"},
+		{snet: "2001:db8::/64", want: "2001:db8::-2001:db8::ffff:ffff:ffff:ffff"},
+	}
+
+	for _, test := range rangeTests {
+		s, _ := ParseCIDR(test.snet)
+		r, err := s.Range()
+		if err != nil || r.String() != test.want {
+			t.Error("Error getting .Range() for", test.snet, "Expected:", test.want, "Got:", r.String(), "With Error:", err)
+		}
+	}
+}
+
+func TestIPSetFromRanges(t *testing.T) {
+	a, _ := ParseRange("10.0.0.5-10.0.0.77")
+	b, _ := ParseRange("10.0.0.78-10.0.0.255")
+
+	set, err := IPSetFromRanges(a, b)
+	if err != nil {
+		t.Fatal("Error creating IPSet from ranges, Error:", err)
+	}
+
+	want := []string{"10.0.0.5/32", "10.0.0.6/31", "10.0.0.8/29", "10.0.0.16/28", "10.0.0.32/27", "10.0.0.64/26", "10.0.0.128/25"}
+	if got := subnetStrings(set.Prefixes()); !slices.Equal(got, want) {
+		t.Error("Error getting .Prefixes() from ranges, Expected:", want, "Got:", got)
+	}
+
+	if _, err := IPSetFromRanges(IPRange{}); err == nil {
+		t.Error("Error creating IPSet from an invalid range, Expected an error")
+	}
+}
+
+func TestUsableRange(t *testing.T) {
+	usableTests := []struct {
+		snet string
+		want string
+	}{
+		{snet: "192.168.20.15/23", want: "192.168.20.1-192.168.21.254"},
+		{snet: "10.0.0.0/31", want: "10.0.0.0-10.0.0.1"},
+		{snet: "10.0.0.9/32", want: "10.0.0.9-10.0.0.9"},
+		{snet: "2001:db8::/64", want: "2001:db8::1-2001:db8::ffff:ffff:ffff:ffff"},
+		{snet: "2001:db8::/127", want: "2001:db8::-2001:db8::1"},
+	}
+
+	for _, test := range usableTests {
+		s, _ := ParseCIDR(test.snet)
+		r, err := s.UsableRange()
+		if err != nil || r.String() != test.want {
+			t.Error("Error getting .UsableRange() for", test.snet, "Expected:", test.want, "Got:", r.String(), "With Error:", err)
+		}
+	}
+}
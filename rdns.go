@@ -0,0 +1,333 @@
+package netmath
+
+import (
+	"fmt"
+	"iter"
+	"math/big"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// Get the PTR owner name of the address ex. 192.0.2.5 -> 5.2.0.192.in-addr.arpa.
+func PTRName(addr netip.Addr) string {
+	if !addr.IsValid() {
+		return ""
+	}
+
+	var labels []string
+	if addr.Is4() {
+		b := addr.As4()
+		for i := 3; i >= 0; i-- {
+			labels = append(labels, strconv.Itoa(int(b[i])))
+		}
+		return strings.Join(labels, ".") + ".in-addr.arpa."
+	}
+
+	b := addr.As16()
+	for i := 15; i >= 0; i-- {
+		labels = append(labels, strconv.FormatUint(uint64(b[i]&0x0f), 16), strconv.FormatUint(uint64(b[i]>>4), 16))
+	}
+	return strings.Join(labels, ".") + ".ip6.arpa."
+}
+
+// Iterate over the hosts of the subnet and their PTR owner names
+func (s Subnet) PTRNames(opts IterOptions) (iter.Seq2[netip.Addr, string], error) {
+	hosts, err := s.Hosts(false, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(yield func(netip.Addr, string) bool) {
+		for addr := range hosts {
+			if !yield(addr, PTRName(addr)) {
+				return
+			}
+		}
+	}, nil
+}
+
+// Get the reverse zone names that cover the subnet. Prefixes off an octet (IPv4) or nibble (IPv6) boundary are split
+// into the zones of the next boundary, except IPv4 prefixes longer than /24 which use RFC 2317 classless names.
+// ex. 192.0.2.0/23 -> [2.0.192.in-addr.arpa. 3.0.192.in-addr.arpa.], 192.0.2.64/26 -> [64/26.2.0.192.in-addr.arpa.]
+func (s Subnet) ReverseZones() ([]string, error) {
+	zones, err := reverseZoneSubnets(s)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(zones))
+	for _, z := range zones {
+		names = append(names, reverseZoneName(z))
+	}
+	return names, nil
+}
+
+// Split the subnet into the subnets that each make up one reverse zone
+func reverseZoneSubnets(s Subnet) ([]Subnet, error) {
+	if !s.IsValid() {
+		return nil, ErrInvalidPrefix
+	}
+	s = NewSubnet(s.Masked())
+
+	step := 4
+	if s.Addr().Is4() {
+		if s.Bits() > 24 {
+			return []Subnet{s}, nil
+		}
+		step = 8
+	}
+
+	bits := (s.Bits() + step - 1) / step * step
+	return s.Split(bits)
+}
+
+// Name of the reverse zone for a subnet from reverseZoneSubnets
+func reverseZoneName(z Subnet) string {
+	name := PTRName(z.Addr())
+	if z.Addr().Is4() {
+		labels := strings.Split(name, ".")
+		if z.Bits() > 24 {
+			return labels[0] + "/" + strconv.Itoa(z.Bits()) + "." + strings.Join(labels[1:], ".")
+		}
+		return strings.Join(labels[4-z.Bits()/8:], ".")
+	}
+
+	// Each nibble label is two characters including its dot
+	return name[(128-z.Bits())/4*2:]
+}
+
+// Settings for the BIND zone skeletons produced by ReverseZoneFiles
+type ZoneOptions struct {
+	NameServers []string // The first name server is the SOA primary ex. ns1.example.com.
+	Hostmaster  string   // SOA contact in DNS form ex. hostmaster.example.com.
+	Serial      uint32
+	TTL         int // Default TTL in seconds, 0 uses 3600
+	// Target name of the PTR record for each host, nil leaves the zone without PTR records
+	PTR func(netip.Addr) string
+}
+
+// BIND zone skeleton for one reverse zone
+type ZoneFile struct {
+	Name    string
+	Content string
+	// Records to add to the parent /24 zone for an RFC 2317 classless zone, empty otherwise
+	Delegation string
+}
+
+// Render a BIND zone skeleton for every reverse zone of the subnet
+func (s Subnet) ReverseZoneFiles(opts ZoneOptions) ([]ZoneFile, error) {
+	if len(opts.NameServers) == 0 || opts.Hostmaster == "" {
+		return nil, fmt.Errorf("zone needs name servers and a hostmaster")
+	}
+	ttl := opts.TTL
+	if ttl == 0 {
+		ttl = 3600
+	}
+
+	zones, err := reverseZoneSubnets(s)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []ZoneFile
+	for _, z := range zones {
+		name := reverseZoneName(z)
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "$ORIGIN %s\n$TTL %d\n", name, ttl)
+		fmt.Fprintf(&b, "@\tIN\tSOA\t%s %s (\n", opts.NameServers[0], opts.Hostmaster)
+		fmt.Fprintf(&b, "\t\t%d\t; serial\n\t\t3600\t; refresh\n\t\t900\t; retry\n\t\t1209600\t; expire\n\t\t%d )\t; negative cache TTL\n", opts.Serial, ttl)
+		for _, ns := range opts.NameServers {
+			fmt.Fprintf(&b, "@\tIN\tNS\t%s\n", ns)
+		}
+
+		if opts.PTR != nil {
+			hostCount, _ := z.CountExact()
+			if hostCount.Cmp(big.NewInt(maxSplit)) > 0 {
+				return nil, fmt.Errorf("too many hosts in %s, zone holds more than %d", name, maxSplit)
+			}
+
+			names, _ := z.PTRNames(IterOptions{})
+			for addr, ptr := range names {
+				fmt.Fprintf(&b, "%s\tIN\tPTR\t%s\n", zoneOwner(ptr, z), opts.PTR(addr))
+			}
+		}
+
+		file := ZoneFile{Name: name, Content: b.String()}
+		if z.Addr().Is4() && z.Bits() > 24 {
+			file.Delegation = classlessDelegation(z, name, opts.NameServers)
+		}
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+// Owner name of a PTR record relative to the origin of the zone holding the subnet
+func zoneOwner(ptr string, z Subnet) string {
+	if z.Addr().Is4() && z.Bits() > 24 {
+		// An RFC 2317 zone holds the last octet under its classless label
+		owner, _, _ := strings.Cut(ptr, ".")
+		return owner
+	}
+
+	zone := reverseZoneName(z)
+	if ptr == zone {
+		return "@"
+	}
+	return strings.TrimSuffix(ptr, "."+zone)
+}
+
+// NS and CNAME records that delegate an RFC 2317 zone from its parent /24 zone
+func classlessDelegation(z Subnet, name string, nameServers []string) string {
+	label, _, _ := strings.Cut(name, ".")
+
+	var b strings.Builder
+	for _, ns := range nameServers {
+		fmt.Fprintf(&b, "%s\tIN\tNS\t%s\n", label, ns)
+	}
+
+	names, _ := z.PTRNames(IterOptions{})
+	for _, ptr := range names {
+		owner := zoneOwner(ptr, z)
+		fmt.Fprintf(&b, "%s\tIN\tCNAME\t%s.%s\n", owner, owner, name)
+	}
+	return b.String()
+}
+
+// Parse a PTR owner name into its address, including names inside an RFC 2317 zone
+// ex. 5.2.0.192.in-addr.arpa. -> 192.0.2.5, 5.0/26.2.0.192.in-addr.arpa -> 192.0.2.5.
+// Failures are reported as a *ParseError.
+func ParsePTRName(name string) (netip.Addr, error) {
+	s, err := parseReverseName(name, ErrInvalidAddr)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	if !s.IsSingleIP() {
+		return netip.Addr{}, newParseError(name, 0, ErrInvalidAddr, ErrSyntax)
+	}
+	return s.Addr(), nil
+}
+
+// Parse a reverse zone or PTR name into the subnet it covers, the trailing dot is optional
+// ex. 2.0.192.in-addr.arpa. -> 192.0.2.0/24, 0/26.2.0.192.in-addr.arpa -> 192.0.2.0/26, 8.b.d.0.1.0.0.2.ip6.arpa -> 2001:db8::/32.
+// Failures are reported as a *ParseError.
+func ParseReverseZone(name string) (Subnet, error) {
+	return parseReverseName(name, ErrInvalidPrefix)
+}
+
+func parseReverseName(name string, category error) (Subnet, error) {
+	trimmed := strings.ToLower(strings.TrimSuffix(name, "."))
+
+	var rest string
+	var is4 bool
+	switch {
+	case trimmed == "in-addr.arpa" || trimmed == "ip6.arpa":
+		is4 = trimmed == "in-addr.arpa"
+	case strings.HasSuffix(trimmed, ".in-addr.arpa"):
+		rest, is4 = strings.TrimSuffix(trimmed, ".in-addr.arpa"), true
+	case strings.HasSuffix(trimmed, ".ip6.arpa"):
+		rest = strings.TrimSuffix(trimmed, ".ip6.arpa")
+	default:
+		return Subnet{}, newParseError(name, len(name), category, ErrSyntax)
+	}
+
+	// Labels in address order, most significant first, with their offsets in name
+	var labels []string
+	var offsets []int
+	if rest != "" {
+		start := 0
+		for _, label := range strings.Split(rest, ".") {
+			labels = append([]string{label}, labels...)
+			offsets = append([]int{start}, offsets...)
+			start += len(label) + 1
+		}
+	}
+
+	var s Subnet
+	var offset int
+	var reason error
+	if is4 {
+		s, offset, reason = parseReverse4(labels, offsets)
+	} else {
+		s, offset, reason = parseReverse6(labels, offsets)
+	}
+	if reason != nil {
+		return Subnet{}, newParseError(name, offset, category, reason)
+	}
+	return s, nil
+}
+
+// Parse in-addr.arpa labels in address order, reporting the offset and reason of a failure
+func parseReverse4(labels []string, offsets []int) (Subnet, int, error) {
+	var b [4]byte
+	bits, sawClassless := 0, false
+	for i, label := range labels {
+		octetStr, bitsStr, classless := strings.Cut(label, "/")
+		// Only an RFC 2317 zone, /32 included, holds a host label past the fourth octet
+		if i > 4 || (i == 4 && !sawClassless) || (classless && i != 3) {
+			return Subnet{}, offsets[i], ErrSyntax
+		}
+
+		n, reason := parseReverseOctet(octetStr)
+		if reason != nil {
+			return Subnet{}, offsets[i], reason
+		}
+
+		switch {
+		case i == 4:
+			// Host label inside an RFC 2317 zone
+			if n&byte(0xff<<(32-bits)) != b[3] {
+				return Subnet{}, offsets[i], ErrOctetOutOfRange
+			}
+			b[3], bits = n, 32
+		case classless:
+			// RFC 2317 classless label <octet>/<bits>
+			classBits, err := strconv.Atoi(bitsStr)
+			if err != nil || strconv.Itoa(classBits) != bitsStr {
+				return Subnet{}, offsets[i] + len(octetStr) + 1, ErrSyntax
+			}
+			if classBits <= 24 || classBits > 32 {
+				return Subnet{}, offsets[i] + len(octetStr) + 1, ErrBitsOutOfRange
+			}
+			if n&^byte(0xff<<(32-classBits)) != 0 {
+				return Subnet{}, offsets[i], ErrHostBitsSet
+			}
+			b[3], bits, sawClassless = n, classBits, true
+		default:
+			b[i], bits = n, bits+8
+		}
+	}
+
+	return NewSubnet(netip.PrefixFrom(netip.AddrFrom4(b), bits)), 0, nil
+}
+
+// Parse a decimal octet label without leading zeros
+func parseReverseOctet(label string) (byte, error) {
+	n, err := strconv.Atoi(label)
+	if err != nil || strconv.Itoa(n) != label {
+		return 0, ErrSyntax
+	}
+	if n > 255 {
+		return 0, ErrOctetOutOfRange
+	}
+	return byte(n), nil
+}
+
+// Parse ip6.arpa nibble labels in address order, reporting the offset and reason of a failure
+func parseReverse6(labels []string, offsets []int) (Subnet, int, error) {
+	if len(labels) > 32 {
+		return Subnet{}, offsets[32], ErrSyntax
+	}
+
+	var b [16]byte
+	for i, label := range labels {
+		if len(label) != 1 || !isHex(label[0]) {
+			return Subnet{}, offsets[i], ErrSyntax
+		}
+		n, _ := strconv.ParseUint(label, 16, 8)
+		b[i/2] |= byte(n) << (4 * (1 - i%2))
+	}
+	return NewSubnet(netip.PrefixFrom(netip.AddrFrom16(b), len(labels)*4)), 0, nil
+}
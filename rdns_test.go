@@ -0,0 +1,291 @@
+package netmath
+
+import (
+	"errors"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestPTRName(t *testing.T) {
+	ptrTests := []struct {
+		addr   string
+		result string
+	}{
+		{addr: "192.0.2.5", result: "5.2.0.192.in-addr.arpa."},
+		{addr: "10.0.0.1", result: "1.0.0.10.in-addr.arpa."},
+		{addr: "2001:db8::567:89ab", result: "b.a.9.8.7.6.5.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa."},
+	}
+
+	for _, test := range ptrTests {
+		result := PTRName(netip.MustParseAddr(test.addr))
+		if result != test.result {
+			t.Error("Error getting PTRName() for", test.addr, "Expected:", test.result, "Got:", result)
+		}
+	}
+
+	if PTRName(netip.Addr{}) != "" {
+		t.Error("Error getting PTRName() for the zero Addr", "Expected: \"\" Got:", PTRName(netip.Addr{}))
+	}
+}
+
+func TestPTRNames(t *testing.T) {
+	s, _ := ParseCIDR("192.0.2.4/30")
+	names, err := s.PTRNames(IterOptions{})
+	if err != nil {
+		t.Fatal("Error getting .PTRNames() for", s, "Expected: <nil> Got:", err)
+	}
+
+	var result []string
+	for addr, ptr := range names {
+		result = append(result, addr.String()+"="+ptr)
+	}
+
+	expected := []string{
+		"192.0.2.4=4.2.0.192.in-addr.arpa.",
+		"192.0.2.5=5.2.0.192.in-addr.arpa.",
+		"192.0.2.6=6.2.0.192.in-addr.arpa.",
+		"192.0.2.7=7.2.0.192.in-addr.arpa.",
+	}
+	if !slices.Equal(result, expected) {
+		t.Error("Error getting .PTRNames() for", s, "Expected:", expected, "Got:", result)
+	}
+}
+
+func TestReverseZones(t *testing.T) {
+	zoneTests := []struct {
+		cidr   string
+		result []string
+	}{
+		{cidr: "192.0.2.0/24", result: []string{"2.0.192.in-addr.arpa."}},
+		{cidr: "10.0.0.0/8", result: []string{"10.in-addr.arpa."}},
+		{cidr: "192.0.2.0/23", result: []string{"2.0.192.in-addr.arpa.", "3.0.192.in-addr.arpa."}},
+		{cidr: "172.16.0.0/15", result: []string{"16.172.in-addr.arpa.", "17.172.in-addr.arpa."}},
+		{cidr: "192.0.2.64/26", result: []string{"64/26.2.0.192.in-addr.arpa."}},
+		{cidr: "192.0.2.77/26", result: []string{"64/26.2.0.192.in-addr.arpa."}},
+		{cidr: "192.0.2.5/32", result: []string{"5/32.2.0.192.in-addr.arpa."}},
+		{cidr: "0.0.0.0/0", result: []string{"in-addr.arpa."}},
+		{cidr: "2001:db8::/32", result: []string{"8.b.d.0.1.0.0.2.ip6.arpa."}},
+		{cidr: "2001:db8:40::/42", result: []string{"4.0.0.8.b.d.0.1.0.0.2.ip6.arpa.", "5.0.0.8.b.d.0.1.0.0.2.ip6.arpa.", "6.0.0.8.b.d.0.1.0.0.2.ip6.arpa.", "7.0.0.8.b.d.0.1.0.0.2.ip6.arpa."}},
+		{cidr: "2001:db8:0:1::/64", result: []string{"1.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa."}},
+	}
+
+	for _, test := range zoneTests {
+		s, _ := ParseCIDR(test.cidr)
+		result, err := s.ReverseZones()
+		if err != nil || !slices.Equal(result, test.result) {
+			t.Error("Error getting .ReverseZones() for", test.cidr, "Expected:", test.result, "Got:", result, err)
+		}
+	}
+
+	if _, err := (Subnet{}).ReverseZones(); err == nil {
+		t.Error("Error getting .ReverseZones() for the zero Subnet", "Expected an error Got:", err)
+	}
+}
+
+func TestReverseZoneFiles(t *testing.T) {
+	opts := ZoneOptions{
+		NameServers: []string{"ns1.example.com.", "ns2.example.com."},
+		Hostmaster:  "hostmaster.example.com.",
+		Serial:      2026101601,
+		PTR: func(addr netip.Addr) string {
+			return "host-" + strings.ReplaceAll(addr.String(), ".", "-") + ".example.com."
+		},
+	}
+
+	s, _ := ParseCIDR("192.0.2.64/30")
+	files, err := s.ReverseZoneFiles(opts)
+	if err != nil || len(files) != 1 {
+		t.Fatal("Error getting .ReverseZoneFiles() for", s, "Expected: 1 zone Got:", len(files), err)
+	}
+
+	content := "$ORIGIN 64/30.2.0.192.in-addr.arpa.\n$TTL 3600\n" +
+		"@\tIN\tSOA\tns1.example.com. hostmaster.example.com. (\n" +
+		"\t\t2026101601\t; serial\n\t\t3600\t; refresh\n\t\t900\t; retry\n\t\t1209600\t; expire\n\t\t3600 )\t; negative cache TTL\n" +
+		"@\tIN\tNS\tns1.example.com.\n@\tIN\tNS\tns2.example.com.\n" +
+		"64\tIN\tPTR\thost-192-0-2-64.example.com.\n" +
+		"65\tIN\tPTR\thost-192-0-2-65.example.com.\n" +
+		"66\tIN\tPTR\thost-192-0-2-66.example.com.\n" +
+		"67\tIN\tPTR\thost-192-0-2-67.example.com.\n"
+	if files[0].Name != "64/30.2.0.192.in-addr.arpa." || files[0].Content != content {
+		t.Error("Error getting .ReverseZoneFiles() for", s, "Expected:", content, "Got:", files[0].Name, files[0].Content)
+	}
+
+	delegation := "64/30\tIN\tNS\tns1.example.com.\n64/30\tIN\tNS\tns2.example.com.\n" +
+		"64\tIN\tCNAME\t64.64/30.2.0.192.in-addr.arpa.\n" +
+		"65\tIN\tCNAME\t65.64/30.2.0.192.in-addr.arpa.\n" +
+		"66\tIN\tCNAME\t66.64/30.2.0.192.in-addr.arpa.\n" +
+		"67\tIN\tCNAME\t67.64/30.2.0.192.in-addr.arpa.\n"
+	if files[0].Delegation != delegation {
+		t.Error("Error getting .ReverseZoneFiles() delegation for", s, "Expected:", delegation, "Got:", files[0].Delegation)
+	}
+
+	wide, _ := ParseCIDR("10.0.0.0/16")
+	files, err = wide.ReverseZoneFiles(opts)
+	if err != nil || len(files) != 1 || files[0].Name != "0.10.in-addr.arpa." || files[0].Delegation != "" {
+		t.Fatal("Error getting .ReverseZoneFiles() for", wide, "Expected: zone 0.10.in-addr.arpa. Got:", files, err)
+	}
+	owners := map[string]bool{}
+	for _, line := range strings.Split(files[0].Content, "\n") {
+		if owner, _, ok := strings.Cut(line, "\tIN\tPTR\t"); ok {
+			owners[owner] = true
+		}
+	}
+	if len(owners) != 65536 || !owners["5.1"] || !owners["0.0"] || !owners["255.255"] {
+		t.Error("Error getting .ReverseZoneFiles() for", wide, "Expected: 65536 owners including 5.1 Got:", len(owners))
+	}
+	if !strings.Contains(files[0].Content, "\n5.1\tIN\tPTR\thost-10-0-1-5.example.com.\n") {
+		t.Error("Error getting .ReverseZoneFiles() for", wide, "Expected a PTR record 5.1 for 10.0.1.5")
+	}
+
+	s6, _ := ParseCIDR("2001:db8::/124")
+	files, err = s6.ReverseZoneFiles(opts)
+	if err != nil || len(files) != 1 || files[0].Delegation != "" {
+		t.Fatal("Error getting .ReverseZoneFiles() for", s6, "Expected: 1 zone Got:", files, err)
+	}
+	if !strings.Contains(files[0].Content, "\n3\tIN\tPTR\thost-2001:db8::3.example.com.\n") {
+		t.Error("Error getting .ReverseZoneFiles() for", s6, "Expected a PTR record for 2001:db8::3 Got:", files[0].Content)
+	}
+
+	host6, _ := ParseCIDR("2001:db8::1/128")
+	files, err = host6.ReverseZoneFiles(opts)
+	if err != nil || len(files) != 1 || !strings.HasSuffix(files[0].Content, "\n@\tIN\tPTR\thost-2001:db8::1.example.com.\n") {
+		t.Error("Error getting .ReverseZoneFiles() for", host6, "Expected a PTR record at the zone apex Got:", files, err)
+	}
+
+	big6, _ := ParseCIDR("2001:db8::/64")
+	if _, err := big6.ReverseZoneFiles(opts); err == nil {
+		t.Error("Error getting .ReverseZoneFiles() for", big6, "Expected an error Got:", err)
+	}
+	opts.PTR = nil
+	if files, err := big6.ReverseZoneFiles(opts); err != nil || strings.Contains(files[0].Content, "PTR") {
+		t.Error("Error getting .ReverseZoneFiles() without PTR records for", big6, "Expected no PTR records Got:", err)
+	}
+	if _, err := big6.ReverseZoneFiles(ZoneOptions{}); err == nil {
+		t.Error("Error getting .ReverseZoneFiles() without name servers", "Expected an error Got:", err)
+	}
+}
+
+func TestParsePTRName(t *testing.T) {
+	ptrTests := []struct {
+		name   string
+		result string
+		offset int
+		err    error
+	}{
+		{name: "5.2.0.192.in-addr.arpa.", result: "192.0.2.5"},
+		{name: "5.2.0.192.IN-ADDR.ARPA", result: "192.0.2.5"},
+		{name: "5.0/26.2.0.192.in-addr.arpa", result: "192.0.2.5"},
+		{name: "70.64/26.2.0.192.in-addr.arpa.", result: "192.0.2.70"},
+		{name: "5.5/32.2.0.192.in-addr.arpa.", result: "192.0.2.5"},
+		{name: "b.a.9.8.7.6.5.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.", result: "2001:db8::567:89ab"},
+		{name: "2.0.192.in-addr.arpa.", offset: 0, err: ErrSyntax},
+		{name: "0/26.2.0.192.in-addr.arpa", offset: 0, err: ErrSyntax},
+		{name: "70.0/26.2.0.192.in-addr.arpa", offset: 0, err: ErrOctetOutOfRange},
+		{name: "6.5/32.2.0.192.in-addr.arpa", offset: 0, err: ErrOctetOutOfRange},
+		{name: "5.5.2.0.192.in-addr.arpa", offset: 0, err: ErrSyntax},
+		{name: "5.2.0.256.in-addr.arpa", offset: 6, err: ErrOctetOutOfRange},
+		{name: "5.2.00.192.in-addr.arpa", offset: 4, err: ErrSyntax},
+		{name: "5.2.0.192.example.com", offset: 21, err: ErrSyntax},
+	}
+
+	for _, test := range ptrTests {
+		addr, err := ParsePTRName(test.name)
+		if test.err != nil {
+			var pe *ParseError
+			if !errors.As(err, &pe) || !errors.Is(err, test.err) || !errors.Is(err, ErrInvalidAddr) || pe.Offset != test.offset {
+				t.Error("Error getting ParsePTRName() for", test.name, "Expected:", test.err, "at offset", test.offset, "Got:", err)
+			}
+			continue
+		}
+		if err != nil || addr.String() != test.result {
+			t.Error("Error getting ParsePTRName() for", test.name, "Expected:", test.result, "Got:", addr, err)
+		}
+	}
+}
+
+func TestParseReverseZone(t *testing.T) {
+	zoneTests := []struct {
+		name   string
+		result string
+		offset int
+		err    error
+	}{
+		{name: "2.0.192.in-addr.arpa.", result: "192.0.2.0/24"},
+		{name: "10.in-addr.arpa", result: "10.0.0.0/8"},
+		{name: "in-addr.arpa.", result: "0.0.0.0/0"},
+		{name: "0/26.2.0.192.in-addr.arpa", result: "192.0.2.0/26"},
+		{name: "128/25.2.0.192.in-addr.arpa.", result: "192.0.2.128/25"},
+		{name: "5.2.0.192.in-addr.arpa.", result: "192.0.2.5/32"},
+		{name: "8.b.d.0.1.0.0.2.ip6.arpa", result: "2001:db8::/32"},
+		{name: "4.0.0.8.B.D.0.1.0.0.2.ip6.arpa.", result: "2001:db8:40::/44"},
+		{name: "ip6.arpa", result: "::/0"},
+		{name: "1/26.2.0.192.in-addr.arpa", offset: 0, err: ErrHostBitsSet},
+		{name: "0/24.2.0.192.in-addr.arpa", offset: 2, err: ErrBitsOutOfRange},
+		{name: "0/2x.2.0.192.in-addr.arpa", offset: 2, err: ErrSyntax},
+		{name: "2.0/26.192.in-addr.arpa", offset: 2, err: ErrSyntax},
+		{name: "1.5.2.0.192.in-addr.arpa", offset: 0, err: ErrSyntax},
+		{name: "g.8.b.d.0.1.0.0.2.ip6.arpa", offset: 0, err: ErrSyntax},
+		{name: "10.8.b.d.0.1.0.0.2.ip6.arpa", offset: 0, err: ErrSyntax},
+	}
+
+	for _, test := range zoneTests {
+		s, err := ParseReverseZone(test.name)
+		if test.err != nil {
+			var pe *ParseError
+			if !errors.As(err, &pe) || !errors.Is(err, test.err) || !errors.Is(err, ErrInvalidPrefix) || pe.Offset != test.offset {
+				t.Error("Error getting ParseReverseZone() for", test.name, "Expected:", test.err, "at offset", test.offset, "Got:", err)
+			}
+			continue
+		}
+		if err != nil || s.String() != test.result {
+			t.Error("Error getting ParseReverseZone() for", test.name, "Expected:", test.result, "Got:", s, err)
+		}
+	}
+}
+
+func TestReverseZoneRoundTrip(t *testing.T) {
+	cidrs := []string{"10.0.0.0/8", "192.0.2.0/23", "192.0.2.128/25", "192.0.2.64/26", "192.0.2.32/27", "192.0.2.16/28", "192.0.2.8/29", "192.0.2.4/30", "192.0.2.6/31", "192.0.2.5/32", "2001:db8::/32", "2001:db8:40::/42", "2001:db8::1/128"}
+	for _, cidr := range cidrs {
+		s, _ := ParseCIDR(cidr)
+		zones, _ := s.ReverseZones()
+		for _, zone := range zones {
+			z, err := ParseReverseZone(zone)
+			if err != nil || !s.ContainsOrEqualSubnet(z) {
+				t.Error("Error getting ParseReverseZone() for", zone, "Expected a subnet of", cidr, "Got:", z, err)
+			}
+		}
+
+		if s.Bits() < s.Addr().BitLen()-8 {
+			continue
+		}
+		names, _ := s.PTRNames(IterOptions{})
+		for addr, name := range names {
+			if got, err := ParsePTRName(name); err != nil || got != addr {
+				t.Error("Error getting ParsePTRName() for", name, "Expected:", addr, "Got:", got, err)
+			}
+		}
+
+		// Every CNAME target delegated into an RFC 2317 zone names an address of the subnet
+		files, _ := s.ReverseZoneFiles(ZoneOptions{NameServers: []string{"ns1.example.com."}, Hostmaster: "hostmaster.example.com."})
+		targets := 0
+		for _, file := range files {
+			for _, line := range strings.Split(file.Delegation, "\n") {
+				fields := strings.Split(line, "\t")
+				if len(fields) != 4 || fields[2] != "CNAME" {
+					continue
+				}
+				targets++
+				addr, err := ParsePTRName(fields[3])
+				if err != nil || !s.Contains(addr) || strconv.Itoa(int(addr.As4()[3])) != fields[0] {
+					t.Error("Error getting ParsePTRName() for", fields[3], "Expected an address of", cidr, "ending in", fields[0], "Got:", addr, err)
+				}
+			}
+		}
+		if s.Addr().Is4() && s.Bits() > 24 && targets != 1<<(32-s.Bits()) {
+			t.Error("Error getting .ReverseZoneFiles() delegation for", cidr, "Expected:", 1<<(32-s.Bits()), "CNAMEs Got:", targets)
+		}
+	}
+}
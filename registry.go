@@ -0,0 +1,215 @@
+package netmath
+
+import (
+	"net/netip"
+	"strconv"
+)
+
+// Broad purpose of a special-purpose address block
+type Purpose int
+
+const (
+	PurposeReserved      Purpose = iota // 0.0.0.0/8, 240.0.0.0/4, ::/128
+	PurposePrivate                      // 10.0.0.0/8, fc00::/7
+	PurposeShared                       // 100.64.0.0/10
+	PurposeLoopback                     // 127.0.0.0/8, ::1/128
+	PurposeLinkLocal                    // 169.254.0.0/16, fe80::/10
+	PurposeDocumentation                // 192.0.2.0/24, 2001:db8::/32
+	PurposeBenchmarking                 // 198.18.0.0/15, 2001:2::/48
+	PurposeMulticast                    // 224.0.0.0/4, ff00::/8
+	PurposeTransition                   // 2002::/16, 2001::/32, 64:ff9b::/96
+	PurposeAnycast                      // 192.0.0.9/32, 192.31.196.0/24
+	PurposeORCHID                       // 2001:20::/28
+)
+
+var purposeNames = map[Purpose]string{
+	PurposeReserved:      "reserved",
+	PurposePrivate:       "private",
+	PurposeShared:        "shared",
+	PurposeLoopback:      "loopback",
+	PurposeLinkLocal:     "link-local",
+	PurposeDocumentation: "documentation",
+	PurposeBenchmarking:  "benchmarking",
+	PurposeMulticast:     "multicast",
+	PurposeTransition:    "transition",
+	PurposeAnycast:       "anycast",
+	PurposeORCHID:        "orchid",
+}
+
+func (p Purpose) String() string {
+	if name, ok := purposeNames[p]; ok {
+		return name
+	}
+	return "Purpose(" + strconv.Itoa(int(p)) + ")"
+}
+
+// Block of the IANA IPv4 or IPv6 special-purpose address registry, or of the multicast address space.
+// The boolean attributes follow the registry columns, where N/A is reported as false.
+type SpecialPurpose struct {
+	Name               string
+	Subnet             Subnet
+	Purpose            Purpose
+	RFC                string
+	Scope              string // Multicast scope ex. link-local, empty for unicast blocks
+	Source             bool   // Valid as a source address
+	Destination        bool   // Valid as a destination address
+	Forwardable        bool   // Routers may forward packets with this address
+	GloballyReachable  bool
+	ReservedByProtocol bool
+}
+
+var specialPurposeBlocks = []SpecialPurpose{
+	{Name: "This network", Subnet: mustParseCIDR("0.0.0.0/8"), Purpose: PurposeReserved, RFC: "RFC 791", Source: true, ReservedByProtocol: true},
+	{Name: "This host on this network", Subnet: mustParseCIDR("0.0.0.0/32"), Purpose: PurposeReserved, RFC: "RFC 1122", Source: true, ReservedByProtocol: true},
+	{Name: "Private-Use", Subnet: mustParseCIDR("10.0.0.0/8"), Purpose: PurposePrivate, RFC: "RFC 1918", Source: true, Destination: true, Forwardable: true},
+	{Name: "Shared Address Space", Subnet: mustParseCIDR("100.64.0.0/10"), Purpose: PurposeShared, RFC: "RFC 6598", Source: true, Destination: true, Forwardable: true},
+	{Name: "Loopback", Subnet: mustParseCIDR("127.0.0.0/8"), Purpose: PurposeLoopback, RFC: "RFC 1122", ReservedByProtocol: true},
+	{Name: "Link Local", Subnet: mustParseCIDR("169.254.0.0/16"), Purpose: PurposeLinkLocal, RFC: "RFC 3927", Source: true, Destination: true, ReservedByProtocol: true},
+	{Name: "Private-Use", Subnet: mustParseCIDR("172.16.0.0/12"), Purpose: PurposePrivate, RFC: "RFC 1918", Source: true, Destination: true, Forwardable: true},
+	{Name: "IETF Protocol Assignments", Subnet: mustParseCIDR("192.0.0.0/24"), Purpose: PurposeReserved, RFC: "RFC 6890"},
+	{Name: "IPv4 Service Continuity Prefix", Subnet: mustParseCIDR("192.0.0.0/29"), Purpose: PurposeTransition, RFC: "RFC 7335", Source: true, Destination: true, Forwardable: true},
+	{Name: "IPv4 dummy address", Subnet: mustParseCIDR("192.0.0.8/32"), Purpose: PurposeReserved, RFC: "RFC 7600", Source: true},
+	{Name: "Port Control Protocol Anycast", Subnet: mustParseCIDR("192.0.0.9/32"), Purpose: PurposeAnycast, RFC: "RFC 7723", Source: true, Destination: true, Forwardable: true, GloballyReachable: true},
+	{Name: "Traversal Using Relays around NAT Anycast", Subnet: mustParseCIDR("192.0.0.10/32"), Purpose: PurposeAnycast, RFC: "RFC 8155", Source: true, Destination: true, Forwardable: true, GloballyReachable: true},
+	{Name: "NAT64/DNS64 Discovery", Subnet: mustParseCIDR("192.0.0.170/32"), Purpose: PurposeTransition, RFC: "RFC 8880", ReservedByProtocol: true},
+	{Name: "NAT64/DNS64 Discovery", Subnet: mustParseCIDR("192.0.0.171/32"), Purpose: PurposeTransition, RFC: "RFC 8880", ReservedByProtocol: true},
+	{Name: "Documentation (TEST-NET-1)", Subnet: mustParseCIDR("192.0.2.0/24"), Purpose: PurposeDocumentation, RFC: "RFC 5737"},
+	{Name: "AS112-v4", Subnet: mustParseCIDR("192.31.196.0/24"), Purpose: PurposeAnycast, RFC: "RFC 7535", Source: true, Destination: true, Forwardable: true, GloballyReachable: true},
+	{Name: "AMT", Subnet: mustParseCIDR("192.52.193.0/24"), Purpose: PurposeAnycast, RFC: "RFC 7450", Source: true, Destination: true, Forwardable: true, GloballyReachable: true},
+	{Name: "Deprecated (6to4 Relay Anycast)", Subnet: mustParseCIDR("192.88.99.0/24"), Purpose: PurposeTransition, RFC: "RFC 7526"},
+	{Name: "6a44-relay anycast address", Subnet: mustParseCIDR("192.88.99.2/32"), Purpose: PurposeTransition, RFC: "RFC 6751", Source: true, Destination: true, Forwardable: true},
+	{Name: "Private-Use", Subnet: mustParseCIDR("192.168.0.0/16"), Purpose: PurposePrivate, RFC: "RFC 1918", Source: true, Destination: true, Forwardable: true},
+	{Name: "Direct Delegation AS112 Service", Subnet: mustParseCIDR("192.175.48.0/24"), Purpose: PurposeAnycast, RFC: "RFC 7534", Source: true, Destination: true, Forwardable: true, GloballyReachable: true},
+	{Name: "Benchmarking", Subnet: mustParseCIDR("198.18.0.0/15"), Purpose: PurposeBenchmarking, RFC: "RFC 2544", Source: true, Destination: true, Forwardable: true},
+	{Name: "Documentation (TEST-NET-2)", Subnet: mustParseCIDR("198.51.100.0/24"), Purpose: PurposeDocumentation, RFC: "RFC 5737"},
+	{Name: "Documentation (TEST-NET-3)", Subnet: mustParseCIDR("203.0.113.0/24"), Purpose: PurposeDocumentation, RFC: "RFC 5737"},
+	{Name: "Multicast", Subnet: mustParseCIDR("224.0.0.0/4"), Purpose: PurposeMulticast, RFC: "RFC 5771", Scope: "global", Destination: true, Forwardable: true, GloballyReachable: true},
+	{Name: "Local Network Control Block", Subnet: mustParseCIDR("224.0.0.0/24"), Purpose: PurposeMulticast, RFC: "RFC 5771", Scope: "link-local", Destination: true, ReservedByProtocol: true},
+	{Name: "Internetwork Control Block", Subnet: mustParseCIDR("224.0.1.0/24"), Purpose: PurposeMulticast, RFC: "RFC 5771", Scope: "global", Destination: true, Forwardable: true, GloballyReachable: true},
+	{Name: "Source-Specific Multicast Block", Subnet: mustParseCIDR("232.0.0.0/8"), Purpose: PurposeMulticast, RFC: "RFC 4607", Scope: "global", Destination: true, Forwardable: true, GloballyReachable: true},
+	{Name: "GLOP Block", Subnet: mustParseCIDR("233.0.0.0/8"), Purpose: PurposeMulticast, RFC: "RFC 3180", Scope: "global", Destination: true, Forwardable: true, GloballyReachable: true},
+	{Name: "Administratively Scoped Block", Subnet: mustParseCIDR("239.0.0.0/8"), Purpose: PurposeMulticast, RFC: "RFC 2365", Scope: "admin-local", Destination: true, Forwardable: true},
+	{Name: "Organization Local Scope", Subnet: mustParseCIDR("239.192.0.0/14"), Purpose: PurposeMulticast, RFC: "RFC 2365", Scope: "organization-local", Destination: true, Forwardable: true},
+	{Name: "IPv4 Local Scope", Subnet: mustParseCIDR("239.255.0.0/16"), Purpose: PurposeMulticast, RFC: "RFC 2365", Scope: "site-local", Destination: true, Forwardable: true},
+	{Name: "Reserved", Subnet: mustParseCIDR("240.0.0.0/4"), Purpose: PurposeReserved, RFC: "RFC 1112", ReservedByProtocol: true},
+	{Name: "Limited Broadcast", Subnet: mustParseCIDR("255.255.255.255/32"), Purpose: PurposeReserved, RFC: "RFC 919", Destination: true, ReservedByProtocol: true},
+
+	{Name: "Unspecified Address", Subnet: mustParseCIDR("::/128"), Purpose: PurposeReserved, RFC: "RFC 4291", Source: true, ReservedByProtocol: true},
+	{Name: "Loopback Address", Subnet: mustParseCIDR("::1/128"), Purpose: PurposeLoopback, RFC: "RFC 4291", ReservedByProtocol: true},
+	{Name: "IPv4-mapped Address", Subnet: mustParseCIDR("::ffff:0:0/96"), Purpose: PurposeTransition, RFC: "RFC 4291", ReservedByProtocol: true},
+	{Name: "IPv4-IPv6 Translation", Subnet: mustParseCIDR("64:ff9b::/96"), Purpose: PurposeTransition, RFC: "RFC 6052", Source: true, Destination: true, Forwardable: true, GloballyReachable: true},
+	{Name: "IPv4-IPv6 Local-Use Translation", Subnet: mustParseCIDR("64:ff9b:1::/48"), Purpose: PurposeTransition, RFC: "RFC 8215", Source: true, Destination: true, Forwardable: true},
+	{Name: "Discard-Only Address Block", Subnet: mustParseCIDR("100::/64"), Purpose: PurposeReserved, RFC: "RFC 6666", Source: true, Destination: true, Forwardable: true},
+	{Name: "Dummy IPv6 Prefix", Subnet: mustParseCIDR("100:0:0:1::/64"), Purpose: PurposeReserved, RFC: "RFC 9780", Source: true},
+	{Name: "IETF Protocol Assignments", Subnet: mustParseCIDR("2001::/23"), Purpose: PurposeReserved, RFC: "RFC 2928"},
+	{Name: "TEREDO", Subnet: mustParseCIDR("2001::/32"), Purpose: PurposeTransition, RFC: "RFC 4380", Source: true, Destination: true, Forwardable: true},
+	{Name: "Port Control Protocol Anycast", Subnet: mustParseCIDR("2001:1::1/128"), Purpose: PurposeAnycast, RFC: "RFC 7723", Source: true, Destination: true, Forwardable: true, GloballyReachable: true},
+	{Name: "Traversal Using Relays around NAT Anycast", Subnet: mustParseCIDR("2001:1::2/128"), Purpose: PurposeAnycast, RFC: "RFC 8155", Source: true, Destination: true, Forwardable: true, GloballyReachable: true},
+	{Name: "DNS-SD Service Registration Protocol Anycast", Subnet: mustParseCIDR("2001:1::3/128"), Purpose: PurposeAnycast, RFC: "RFC 9665", Source: true, Destination: true, Forwardable: true, GloballyReachable: true},
+	{Name: "Benchmarking", Subnet: mustParseCIDR("2001:2::/48"), Purpose: PurposeBenchmarking, RFC: "RFC 5180", Source: true, Destination: true, Forwardable: true},
+	{Name: "AMT", Subnet: mustParseCIDR("2001:3::/32"), Purpose: PurposeAnycast, RFC: "RFC 7450", Source: true, Destination: true, Forwardable: true, GloballyReachable: true},
+	{Name: "AS112-v6", Subnet: mustParseCIDR("2001:4:112::/48"), Purpose: PurposeAnycast, RFC: "RFC 7535", Source: true, Destination: true, Forwardable: true, GloballyReachable: true},
+	{Name: "Deprecated (previously ORCHID)", Subnet: mustParseCIDR("2001:10::/28"), Purpose: PurposeORCHID, RFC: "RFC 4843"},
+	{Name: "ORCHIDv2", Subnet: mustParseCIDR("2001:20::/28"), Purpose: PurposeORCHID, RFC: "RFC 7343", Source: true, Destination: true, Forwardable: true, GloballyReachable: true},
+	{Name: "Drone Remote ID Protocol Entity Tags (DETs) Prefix", Subnet: mustParseCIDR("2001:30::/28"), Purpose: PurposeORCHID, RFC: "RFC 9374", Source: true, Destination: true, Forwardable: true, GloballyReachable: true},
+	{Name: "Documentation", Subnet: mustParseCIDR("2001:db8::/32"), Purpose: PurposeDocumentation, RFC: "RFC 3849"},
+	{Name: "6to4", Subnet: mustParseCIDR("2002::/16"), Purpose: PurposeTransition, RFC: "RFC 3056", Source: true, Destination: true, Forwardable: true},
+	{Name: "Direct Delegation AS112 Service", Subnet: mustParseCIDR("2620:4f:8000::/48"), Purpose: PurposeAnycast, RFC: "RFC 7534", Source: true, Destination: true, Forwardable: true, GloballyReachable: true},
+	{Name: "Documentation", Subnet: mustParseCIDR("3fff::/20"), Purpose: PurposeDocumentation, RFC: "RFC 9637"},
+	{Name: "Segment Routing (SRv6) SIDs", Subnet: mustParseCIDR("5f00::/16"), Purpose: PurposeReserved, RFC: "RFC 9602", Source: true, Destination: true, Forwardable: true},
+	{Name: "Unique-Local", Subnet: mustParseCIDR("fc00::/7"), Purpose: PurposePrivate, RFC: "RFC 4193", Source: true, Destination: true, Forwardable: true},
+	{Name: "Link-Local Unicast", Subnet: mustParseCIDR("fe80::/10"), Purpose: PurposeLinkLocal, RFC: "RFC 4291", Source: true, Destination: true, ReservedByProtocol: true},
+	{Name: "Multicast", Subnet: mustParseCIDR("ff00::/8"), Purpose: PurposeMulticast, RFC: "RFC 4291", Destination: true, Forwardable: true},
+}
+
+// IPv6 multicast scopes by the 4 bit scope field ex. ff02::1 -> link-local
+var multicastScopes = map[byte]string{
+	0x1: "interface-local",
+	0x2: "link-local",
+	0x3: "realm-local",
+	0x4: "admin-local",
+	0x5: "site-local",
+	0x8: "organization-local",
+	0xe: "global",
+}
+
+var specialPurposeTable = newSpecialPurposeTable()
+
+func newSpecialPurposeTable() *Table[SpecialPurpose] {
+	t := &Table[SpecialPurpose]{}
+	for _, sp := range specialPurposeBlocks {
+		t.Insert(sp.Subnet, sp)
+	}
+	return t
+}
+
+func mustParseCIDR(s string) Subnet {
+	snet, err := ParseCIDR(s)
+	if err != nil {
+		panic(err)
+	}
+	return snet
+}
+
+// List every block of the special-purpose registry, IPv4 first, in address order
+func SpecialPurposeRegistry() []SpecialPurpose {
+	var blocks []SpecialPurpose
+	for _, sp := range specialPurposeTable.All() {
+		blocks = append(blocks, sp)
+	}
+	return blocks
+}
+
+// Find the most specific special-purpose block that contains the address ex. 192.0.2.10 -> Documentation (TEST-NET-1)
+func ClassifyAddr(addr netip.Addr) (SpecialPurpose, bool) {
+	if !addr.IsValid() {
+		return SpecialPurpose{}, false
+	}
+	return NewSubnet(netip.PrefixFrom(addr, addr.BitLen())).Classify()
+}
+
+// Find the most specific special-purpose block that contains the whole subnet ex. 10.1.0.0/16 -> Private-Use
+func (s Subnet) Classify() (SpecialPurpose, bool) {
+	var match SpecialPurpose
+	var found bool
+	for _, sp := range specialPurposeTable.Covering(s) {
+		match, found = sp, true
+	}
+
+	if found && match.Purpose == PurposeMulticast && s.Addr().Is6() {
+		match = withMulticastScope(match, s)
+	}
+	return match, found
+}
+
+// List every special-purpose block that overlaps the subnet, from least to most specific and then in address order
+// ex. 192.0.0.0/16 -> [IETF Protocol Assignments ... Documentation (TEST-NET-1)]
+func (s Subnet) SpecialPurposes() []SpecialPurpose {
+	var blocks []SpecialPurpose
+	for sub, sp := range specialPurposeTable.Covering(s) {
+		if sub.Bits() < s.Bits() {
+			blocks = append(blocks, sp)
+		}
+	}
+	for _, sp := range specialPurposeTable.Covered(s) {
+		blocks = append(blocks, sp)
+	}
+	return blocks
+}
+
+// Fill in the scope of an IPv6 multicast subnet, which is only known when the subnet fixes the scope field
+func withMulticastScope(sp SpecialPurpose, s Subnet) SpecialPurpose {
+	if s.Bits() < 16 {
+		return sp
+	}
+
+	scope, ok := multicastScopes[s.Addr().As16()[1]&0x0f]
+	if !ok {
+		scope = "reserved"
+	}
+
+	sp.Scope = scope
+	sp.Forwardable = scope != "interface-local" && scope != "link-local"
+	sp.GloballyReachable = scope == "global"
+	return sp
+}
@@ -0,0 +1,135 @@
+package netmath
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestClassifyAddr(t *testing.T) {
+	classifyTests := []struct {
+		addr    string
+		name    string
+		purpose Purpose
+		scope   string
+		global  bool
+		found   bool
+	}{
+		{addr: "10.20.30.40", name: "Private-Use", purpose: PurposePrivate, found: true},
+		{addr: "100.100.1.1", name: "Shared Address Space", purpose: PurposeShared, found: true},
+		{addr: "192.0.2.10", name: "Documentation (TEST-NET-1)", purpose: PurposeDocumentation, found: true},
+		{addr: "198.19.255.255", name: "Benchmarking", purpose: PurposeBenchmarking, found: true},
+		{addr: "0.0.0.0", name: "This host on this network", purpose: PurposeReserved, found: true},
+		{addr: "0.1.2.3", name: "This network", purpose: PurposeReserved, found: true},
+		{addr: "192.0.0.9", name: "Port Control Protocol Anycast", purpose: PurposeAnycast, global: true, found: true},
+		{addr: "192.0.0.100", name: "IETF Protocol Assignments", purpose: PurposeReserved, found: true},
+		{addr: "224.0.0.251", name: "Local Network Control Block", purpose: PurposeMulticast, scope: "link-local", found: true},
+		{addr: "239.255.255.250", name: "IPv4 Local Scope", purpose: PurposeMulticast, scope: "site-local", found: true},
+		{addr: "230.1.1.1", name: "Multicast", purpose: PurposeMulticast, scope: "global", global: true, found: true},
+		{addr: "192.88.99.1", name: "Deprecated (6to4 Relay Anycast)", purpose: PurposeTransition, found: true},
+		{addr: "192.88.99.2", name: "6a44-relay anycast address", purpose: PurposeTransition, found: true},
+		{addr: "255.255.255.255", name: "Limited Broadcast", purpose: PurposeReserved, found: true},
+		{addr: "8.8.8.8"},
+		{addr: "::1", name: "Loopback Address", purpose: PurposeLoopback, found: true},
+		{addr: "64:ff9b::192.0.2.1", name: "IPv4-IPv6 Translation", purpose: PurposeTransition, global: true, found: true},
+		{addr: "2001:db8::1", name: "Documentation", purpose: PurposeDocumentation, found: true},
+		{addr: "3fff:fff::1", name: "Documentation", purpose: PurposeDocumentation, found: true},
+		{addr: "2001:0:4136:e378::1", name: "TEREDO", purpose: PurposeTransition, found: true},
+		{addr: "2001:1::2", name: "Traversal Using Relays around NAT Anycast", purpose: PurposeAnycast, global: true, found: true},
+		{addr: "2001:20::1", name: "ORCHIDv2", purpose: PurposeORCHID, global: true, found: true},
+		{addr: "2002:c000:201::1", name: "6to4", purpose: PurposeTransition, found: true},
+		{addr: "fd12:3456::1", name: "Unique-Local", purpose: PurposePrivate, found: true},
+		{addr: "fe80::1", name: "Link-Local Unicast", purpose: PurposeLinkLocal, found: true},
+		{addr: "ff02::1", name: "Multicast", purpose: PurposeMulticast, scope: "link-local", found: true},
+		{addr: "ff3e::1234", name: "Multicast", purpose: PurposeMulticast, scope: "global", global: true, found: true},
+		{addr: "2606:4700::1111"},
+	}
+
+	for _, test := range classifyTests {
+		sp, found := ClassifyAddr(netip.MustParseAddr(test.addr))
+		if found != test.found || sp.Name != test.name || sp.Purpose != test.purpose || sp.Scope != test.scope || sp.GloballyReachable != test.global {
+			t.Error("Error getting ClassifyAddr() for", test.addr, "Expected:", test.name, test.purpose, test.scope, test.global, test.found, "Got:", sp.Name, sp.Purpose, sp.Scope, sp.GloballyReachable, found)
+		}
+	}
+
+	if _, found := ClassifyAddr(netip.Addr{}); found {
+		t.Error("Error getting ClassifyAddr() for the zero Addr", "Expected:", false, "Got:", found)
+	}
+}
+
+func TestClassify(t *testing.T) {
+	classifyTests := []struct {
+		cidr  string
+		name  string
+		found bool
+	}{
+		{cidr: "10.1.0.0/16", name: "Private-Use", found: true},
+		{cidr: "172.16.0.0/12", name: "Private-Use", found: true},
+		{cidr: "172.0.0.0/8"},
+		{cidr: "192.0.0.0/29", name: "IPv4 Service Continuity Prefix", found: true},
+		{cidr: "2001:db8:abcd::/48", name: "Documentation", found: true},
+		{cidr: "ff00::/8", name: "Multicast", found: true},
+	}
+
+	for _, test := range classifyTests {
+		s, _ := ParseCIDR(test.cidr)
+		sp, found := s.Classify()
+		if found != test.found || sp.Name != test.name {
+			t.Error("Error getting .Classify() for", test.cidr, "Expected:", test.name, test.found, "Got:", sp.Name, found)
+		}
+	}
+}
+
+func TestSpecialPurposes(t *testing.T) {
+	purposeTests := []struct {
+		cidr   string
+		result []string
+	}{
+		{cidr: "192.0.2.128/25", result: []string{"192.0.2.0/24"}},
+		{cidr: "192.0.0.0/22", result: []string{"192.0.0.0/24", "192.0.0.0/29", "192.0.0.8/32", "192.0.0.9/32", "192.0.0.10/32", "192.0.0.170/32", "192.0.0.171/32", "192.0.2.0/24"}},
+		{cidr: "172.0.0.0/8", result: []string{"172.16.0.0/12"}},
+		{cidr: "8.0.0.0/8", result: nil},
+		{cidr: "2000::/3", result: []string{"2001::/23", "2001::/32", "2001:1::1/128", "2001:1::2/128", "2001:1::3/128", "2001:2::/48", "2001:3::/32", "2001:4:112::/48", "2001:10::/28", "2001:20::/28", "2001:30::/28", "2001:db8::/32", "2002::/16", "2620:4f:8000::/48", "3fff::/20"}},
+	}
+
+	for _, test := range purposeTests {
+		s, _ := ParseCIDR(test.cidr)
+		var result []string
+		for _, sp := range s.SpecialPurposes() {
+			result = append(result, sp.Subnet.String())
+		}
+
+		if len(result) != len(test.result) {
+			t.Error("Error getting .SpecialPurposes() for", test.cidr, "Expected:", test.result, "Got:", result)
+			continue
+		}
+		for i := range result {
+			if result[i] != test.result[i] {
+				t.Error("Error getting .SpecialPurposes() for", test.cidr, "Expected:", test.result, "Got:", result)
+				break
+			}
+		}
+	}
+}
+
+func TestBogonsAreSpecialPurpose(t *testing.T) {
+	// Every IPv4 bogon is a registry block, IPv6 bogons such as 3ffe::/16 are returned or deprecated space instead
+	for _, b := range Bogons() {
+		if b.Addr().Is4() && len(b.SpecialPurposes()) == 0 {
+			t.Error("Error getting .SpecialPurposes() for bogon", b, "Expected a registry block Got: none")
+		}
+	}
+}
+
+func TestSpecialPurposeRegistry(t *testing.T) {
+	registry := SpecialPurposeRegistry()
+	if len(registry) != len(specialPurposeBlocks) {
+		t.Error("Error getting SpecialPurposeRegistry()", "Expected:", len(specialPurposeBlocks), "Got:", len(registry))
+	}
+
+	for i := 1; i < len(registry); i++ {
+		prev, cur := registry[i-1].Subnet, registry[i].Subnet
+		if prev.Addr().Is4() == cur.Addr().Is4() && prev.Addr().Compare(cur.Addr()) > 0 {
+			t.Error("Error getting SpecialPurposeRegistry()", "Expected address order Got:", prev, "before", cur)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package netmath
+
+import (
+	"math/big"
+	"net/netip"
+	"slices"
+)
+
+// Address family of an IP address
+type Family int
+
+const (
+	IPv4 Family = 4
+	IPv6 Family = 6
+)
+
+// Immutable set of IPv4 and IPv6 addresses. The zero value is an empty set.
+type IPSet struct {
+	v4 []span // Sorted, non-overlapping and non-adjacent
+	v6 []span
+}
+
+// Create a new IPSet holding every address of the given subnets
+func NewIPSet(subnets ...Subnet) (IPSet, error) {
+	v4, v6, err := familySpans(subnets)
+	if err != nil {
+		return IPSet{}, err
+	}
+	return IPSet{v4: mergeSpans(v4), v6: mergeSpans(v6)}, nil
+}
+
+// Create a new IPSet holding the given individual addresses
+func IPSetFromAddrs(addrs ...netip.Addr) (IPSet, error) {
+	var v4, v6 []span
+	for _, addr := range addrs {
+		if !addr.IsValid() {
+			return IPSet{}, ErrInvalidAddr
+		}
+
+		u := addrToUint128(addr)
+		if addr.Is4() {
+			v4 = append(v4, span{from: u, to: u})
+		} else {
+			v6 = append(v6, span{from: u, to: u})
+		}
+	}
+	return IPSet{v4: mergeSpans(v4), v6: mergeSpans(v6)}, nil
+}
+
+// Get the set of addresses in either set
+func (s IPSet) Union(o IPSet) IPSet {
+	return IPSet{
+		v4: mergeSpans(slices.Concat(s.v4, o.v4)),
+		v6: mergeSpans(slices.Concat(s.v6, o.v6)),
+	}
+}
+
+// Get the set of addresses in both sets
+func (s IPSet) Intersect(o IPSet) IPSet {
+	return IPSet{v4: intersectSpans(s.v4, o.v4), v6: intersectSpans(s.v6, o.v6)}
+}
+
+// Get the set of addresses in s but not in o
+func (s IPSet) Difference(o IPSet) IPSet {
+	return IPSet{v4: subtractSpans(s.v4, o.v4), v6: subtractSpans(s.v6, o.v6)}
+}
+
+// Get every address of the family that is not in the set. Addresses of the other family are dropped.
+// A family other than IPv4 or IPv6 has no addresses, so its complement is the empty set.
+func (s IPSet) Complement(f Family) IPSet {
+	switch f {
+	case IPv4:
+		return IPSet{v4: subtractSpans([]span{{to: hostMask(0, 32)}}, s.v4)}
+	case IPv6:
+		return IPSet{v6: subtractSpans([]span{{to: hostMask(0, 128)}}, s.v6)}
+	}
+	return IPSet{}
+}
+
+// Check if the address is in the set
+func (s IPSet) Contains(addr netip.Addr) bool {
+	if !addr.IsValid() {
+		return false
+	}
+
+	u := addrToUint128(addr)
+	return s.containsSpan(span{from: u, to: u}, addr.Is4())
+}
+
+// Check if every address of the subnet is in the set
+func (s IPSet) ContainsSubnet(sub Subnet) bool {
+	sp, err := subnetSpan(sub)
+	if err != nil {
+		return false
+	}
+	return s.containsSpan(sp, sub.Addr().Is4())
+}
+
+func (s IPSet) containsSpan(sp span, is4 bool) bool {
+	spans := s.v6
+	if is4 {
+		spans = s.v4
+	}
+
+	// Find the first span that ends at or after the start of sp
+	i, _ := slices.BinarySearchFunc(spans, sp.from, func(e span, u uint128) int {
+		return e.to.cmp(u)
+	})
+	return i < len(spans) && spans[i].from.cmp(sp.from) <= 0 && spans[i].to.cmp(sp.to) >= 0
+}
+
+// Check if the set holds no addresses
+func (s IPSet) IsEmpty() bool {
+	return len(s.v4) == 0 && len(s.v6) == 0
+}
+
+// Check if both sets hold the same addresses
+func (s IPSet) Equal(o IPSet) bool {
+	return slices.Equal(s.v4, o.v4) && slices.Equal(s.v6, o.v6)
+}
+
+// Count the exact number of addresses in the set
+func (s IPSet) Count() *big.Int {
+	count := new(big.Int)
+	for _, sp := range slices.Concat(s.v4, s.v6) {
+		count.Add(count, sp.to.sub(sp.from).big())
+		count.Add(count, big.NewInt(1))
+	}
+	return count
+}
+
+// Get the minimal list of subnets that covers the set, IPv4 first
+func (s IPSet) Prefixes() []Subnet {
+	var subnets []Subnet
+	for _, sp := range s.v4 {
+		subnets = append(subnets, spanPrefixes(sp, true)...)
+	}
+	for _, sp := range s.v6 {
+		subnets = append(subnets, spanPrefixes(sp, false)...)
+	}
+	return subnets
+}
+
+// Intersect two sorted and merged span lists
+func intersectSpans(a []span, b []span) []span {
+	var out []span
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		from, to := a[i].from, a[i].to
+		if b[j].from.cmp(from) > 0 {
+			from = b[j].from
+		}
+		if b[j].to.cmp(to) < 0 {
+			to = b[j].to
+		}
+		if from.cmp(to) <= 0 {
+			out = append(out, span{from: from, to: to})
+		}
+
+		if a[i].to.cmp(b[j].to) < 0 {
+			i++
+		} else {
+			j++
+		}
+	}
+	return out
+}
+
+// Remove the spans of b from the spans of a, both sorted and merged
+func subtractSpans(a []span, b []span) []span {
+	var out []span
+	j := 0
+	for _, sp := range a {
+		for j < len(b) && b[j].to.cmp(sp.from) < 0 {
+			j++
+		}
+
+		from := sp.from
+		covered := false
+		for k := j; k < len(b) && b[k].from.cmp(sp.to) <= 0; k++ {
+			if b[k].from.cmp(from) > 0 {
+				out = append(out, span{from: from, to: b[k].from.subOne()})
+			}
+			if b[k].to.cmp(sp.to) >= 0 {
+				covered = true
+				break
+			}
+			from = b[k].to.addOne()
+		}
+
+		if !covered {
+			out = append(out, span{from: from, to: sp.to})
+		}
+	}
+	return out
+}
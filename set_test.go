@@ -0,0 +1,142 @@
+package netmath
+
+import (
+	"net/netip"
+	"slices"
+	"testing"
+)
+
+func mustIPSet(t *testing.T, list ...string) IPSet {
+	t.Helper()
+
+	set, err := NewIPSet(parseSubnets(t, list)...)
+	if err != nil {
+		t.Fatal("Error creating IPSet from", list, "Error:", err)
+	}
+	return set
+}
+
+func TestIPSetOperations(t *testing.T) {
+	setTests := []struct {
+		op   string
+		a    []string
+		b    []string
+		want []string
+	}{
+		{op: "union", a: []string{"10.0.0.0/24"}, b: []string{"10.0.1.0/24"}, want: []string{"10.0.0.0/23"}},
+		{op: "union", a: []string{"10.0.0.0/24"}, b: []string{"2001:db8::/32"}, want: []string{"10.0.0.0/24", "2001:db8::/32"}},
+		{op: "union", a: nil, b: nil, want: nil},
+		{op: "intersect", a: []string{"10.0.0.0/16"}, b: []string{"10.0.5.0/24", "11.0.0.0/8"}, want: []string{"10.0.5.0/24"}},
+		{op: "intersect", a: []string{"10.0.0.0/24", "10.0.2.0/24"}, b: []string{"10.0.0.128/25", "10.0.1.0/24", "10.0.2.0/26"}, want: []string{"10.0.0.128/25", "10.0.2.0/26"}},
+		{op: "intersect", a: []string{"10.0.0.0/24"}, b: []string{"10.0.1.0/24"}, want: nil},
+		{op: "difference", a: []string{"10.0.0.0/24"}, b: []string{"10.0.0.0/25"}, want: []string{"10.0.0.128/25"}},
+		{op: "difference", a: []string{"10.0.0.0/24"}, b: []string{"10.0.0.64/26"}, want: []string{"10.0.0.0/26", "10.0.0.128/25"}},
+		{op: "difference", a: []string{"10.0.0.0/24"}, b: []string{"10.0.0.0/8"}, want: nil},
+		{op: "difference", a: []string{"10.0.0.0/24", "2001:db8::/127"}, b: []string{"2001:db8::1/128"}, want: []string{"10.0.0.0/24", "2001:db8::/128"}},
+		{op: "difference", a: []string{"::/0"}, b: []string{"8000::/1"}, want: []string{"::/1"}},
+	}
+
+	for _, test := range setTests {
+		a, b := mustIPSet(t, test.a...), mustIPSet(t, test.b...)
+
+		var got IPSet
+		switch test.op {
+		case "union":
+			got = a.Union(b)
+		case "intersect":
+			got = a.Intersect(b)
+		case "difference":
+			got = a.Difference(b)
+		}
+
+		if prefixes := subnetStrings(got.Prefixes()); !slices.Equal(prefixes, test.want) {
+			t.Error("Error getting", test.op, "of", test.a, "and", test.b, "Expected:", test.want, "Got:", prefixes)
+		}
+	}
+}
+
+func TestIPSetComplement(t *testing.T) {
+	complementTests := []struct {
+		snets  []string
+		family Family
+		want   []string
+	}{
+		{snets: nil, family: IPv4, want: []string{"0.0.0.0/0"}},
+		{snets: []string{"0.0.0.0/1"}, family: IPv4, want: []string{"128.0.0.0/1"}},
+		{snets: []string{"10.0.0.0/8", "2001:db8::/32"}, family: IPv4, want: []string{"0.0.0.0/5", "8.0.0.0/7", "11.0.0.0/8", "12.0.0.0/6", "16.0.0.0/4", "32.0.0.0/3", "64.0.0.0/2", "128.0.0.0/1"}},
+		{snets: []string{"::/1"}, family: IPv6, want: []string{"8000::/1"}},
+		{snets: []string{"::/0"}, family: IPv6, want: nil},
+		{snets: []string{"10.0.0.0/8"}, family: Family(5), want: nil},
+		{snets: nil, family: Family(0), want: nil},
+	}
+
+	for _, test := range complementTests {
+		got := mustIPSet(t, test.snets...).Complement(test.family)
+		if prefixes := subnetStrings(got.Prefixes()); !slices.Equal(prefixes, test.want) {
+			t.Error("Error getting complement of", test.snets, "Expected:", test.want, "Got:", prefixes)
+		}
+	}
+}
+
+func TestIPSetContains(t *testing.T) {
+	set := mustIPSet(t, "10.0.0.0/24", "10.0.2.0/24", "2001:db8::/64")
+
+	containsTests := []struct {
+		addr string
+		want bool
+	}{
+		{addr: "10.0.0.0", want: true},
+		{addr: "10.0.0.255", want: true},
+		{addr: "10.0.1.0", want: false},
+		{addr: "10.0.2.17", want: true},
+		{addr: "10.0.3.0", want: false},
+		{addr: "2001:db8::1", want: true},
+		{addr: "2001:db8:0:1::", want: false},
+		{addr: "::ffff:10.0.0.1", want: false},
+	}
+
+	for _, test := range containsTests {
+		if got := set.Contains(netip.MustParseAddr(test.addr)); got != test.want {
+			t.Error("Error getting .Contains() for", test.addr, "Expected:", test.want, "Got:", got)
+		}
+	}
+
+	subnetTests := []struct {
+		snet string
+		want bool
+	}{
+		{snet: "10.0.0.128/25", want: true},
+		{snet: "10.0.0.0/23", want: false},
+		{snet: "2001:db8::/64", want: true},
+		{snet: "2001:db8::/63", want: false},
+	}
+
+	for _, test := range subnetTests {
+		s, _ := ParseCIDR(test.snet)
+		if got := set.ContainsSubnet(s); got != test.want {
+			t.Error("Error getting .ContainsSubnet() for", test.snet, "Expected:", test.want, "Got:", got)
+		}
+	}
+}
+
+func TestIPSetFromAddrs(t *testing.T) {
+	set, err := IPSetFromAddrs(netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("10.0.0.2"), netip.MustParseAddr("10.0.0.3"), netip.MustParseAddr("::1"))
+	if err != nil {
+		t.Fatal("Error creating IPSet from addresses, Error:", err)
+	}
+
+	want := []string{"10.0.0.1/32", "10.0.0.2/31", "::1/128"}
+	if got := subnetStrings(set.Prefixes()); !slices.Equal(got, want) {
+		t.Error("Error getting .Prefixes() from addresses, Expected:", want, "Got:", got)
+	}
+	if got := set.Count().String(); got != "4" {
+		t.Error("Error getting .Count() from addresses, Expected: 4 Got:", got)
+	}
+	if !set.Equal(mustIPSet(t, "10.0.0.1/32", "10.0.0.2/31", "::1/128")) {
+		t.Error("Error comparing sets built from addresses and subnets")
+	}
+
+	if _, err := IPSetFromAddrs(netip.Addr{}); err == nil {
+		t.Error("Error creating IPSet from an invalid address, Expected an error")
+	}
+}
@@ -0,0 +1,56 @@
+package netmath
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// Largest number of subnets Split will allocate. Use Children or ChildSeq to walk bigger lists lazily.
+const maxSplit = 1 << 20
+
+// List the child subnets of the given prefix length without allocating them ex. 10.0.0.0/16 by /24 -> 256 subnets
+func (s Subnet) Children(bits int) (SubnetList, error) {
+	return newSubnetList(s.Addr(), s.Bits(), bits)
+}
+
+// Get the n-th child subnet of the given prefix length ex. 10.0.0.0/16 by /24 at 5 -> 10.0.5.0/24
+func (s Subnet) Child(bits int, n *big.Int) (Subnet, error) {
+	list, err := s.Children(bits)
+	if err != nil {
+		return Subnet{}, err
+	}
+	return list.At(n)
+}
+
+// Split the subnet into all children of the given prefix length ex. 10.0.0.0/23 by /24 -> [10.0.0.0/24 10.0.1.0/24]
+func (s Subnet) Split(bits int) ([]Subnet, error) {
+	list, err := s.Children(bits)
+	if err != nil {
+		return nil, err
+	}
+
+	if list.Len().Cmp(big.NewInt(maxSplit)) > 0 {
+		return nil, fmt.Errorf("too many subnets, split produces more than %d", maxSplit)
+	}
+
+	n := list.last().lo
+	subnets := make([]Subnet, 0, n+1)
+	for i := uint64(0); i <= n; i++ {
+		subnets = append(subnets, list.at(uint128{lo: i}))
+	}
+
+	return subnets, nil
+}
+
+// Split the subnet into at least n equal parts. n is rounded up to a power of two ex. 10.0.0.0/22 into 3 -> 4 /24s
+func (s Subnet) SplitN(n int) ([]Subnet, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("invalid number of parts")
+	}
+
+	// Number of extra prefix bits needed to make n parts
+	extra := bits.Len(uint(n - 1))
+
+	return s.Split(s.Bits() + extra)
+}
@@ -0,0 +1,113 @@
+package netmath
+
+import (
+	"math/big"
+	"slices"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	splitTests := []struct {
+		snet string
+		bits int
+		want []string
+	}{
+		{snet: "10.0.0.0/22", bits: 24, want: []string{"10.0.0.0/24", "10.0.1.0/24", "10.0.2.0/24", "10.0.3.0/24"}},
+		{snet: "10.0.0.77/22", bits: 23, want: []string{"10.0.0.0/23", "10.0.2.0/23"}},
+		{snet: "192.168.0.0/30", bits: 32, want: []string{"192.168.0.0/32", "192.168.0.1/32", "192.168.0.2/32", "192.168.0.3/32"}},
+		{snet: "192.168.0.0/24", bits: 24, want: []string{"192.168.0.0/24"}},
+		{snet: "2001:db8::/46", bits: 48, want: []string{"2001:db8::/48", "2001:db8:1::/48", "2001:db8:2::/48", "2001:db8:3::/48"}},
+	}
+
+	for _, test := range splitTests {
+		s, _ := ParseCIDR(test.snet)
+		list, err := s.Split(test.bits)
+		if err != nil {
+			t.Error("Error getting .Split() for", test.snet, "Error:", err)
+			continue
+		}
+
+		var got []string
+		for _, child := range list {
+			got = append(got, child.String())
+		}
+		if !slices.Equal(got, test.want) {
+			t.Error("Error getting .Split() for", test.snet, "Expected:", test.want, "Got:", got)
+		}
+	}
+
+	invalidTests := []struct {
+		snet string
+		bits int
+	}{
+		{snet: "10.0.0.0/16", bits: 15},
+		{snet: "10.0.0.0/16", bits: 33},
+		{snet: "2001:db8::/32", bits: 64},
+	}
+
+	for _, test := range invalidTests {
+		s, _ := ParseCIDR(test.snet)
+		if _, err := s.Split(test.bits); err == nil {
+			t.Error("Error getting .Split() for", test.snet, "by", test.bits, "Expected an error")
+		}
+	}
+}
+
+func TestSplitN(t *testing.T) {
+	splitTests := []struct {
+		snet string
+		n    int
+		want int
+		bits int
+	}{
+		{snet: "10.0.0.0/22", n: 4, want: 4, bits: 24},
+		{snet: "10.0.0.0/22", n: 3, want: 4, bits: 24},
+		{snet: "10.0.0.0/22", n: 5, want: 8, bits: 25},
+		{snet: "10.0.0.0/22", n: 1, want: 1, bits: 22},
+		{snet: "2001:db8::/48", n: 256, want: 256, bits: 56},
+	}
+
+	for _, test := range splitTests {
+		s, _ := ParseCIDR(test.snet)
+		list, err := s.SplitN(test.n)
+		if err != nil || len(list) != test.want || list[0].Bits() != test.bits {
+			t.Error("Error getting .SplitN() for", test.snet, "into", test.n, "Expected:", test.want, "/", test.bits, "Got:", list, "With Error:", err)
+		}
+	}
+
+	s, _ := ParseCIDR("10.0.0.0/31")
+	for _, n := range []int{0, 3} {
+		if _, err := s.SplitN(n); err == nil {
+			t.Error("Error getting .SplitN() for", s, "into", n, "Expected an error")
+		}
+	}
+}
+
+func TestChild(t *testing.T) {
+	childTests := []struct {
+		snet string
+		bits int
+		n    string
+		want string
+	}{
+		{snet: "10.0.0.0/16", bits: 24, n: "5", want: "10.0.5.0/24"},
+		{snet: "10.0.0.0/16", bits: 24, n: "255", want: "10.0.255.0/24"},
+		{snet: "10.0.0.0/8", bits: 32, n: "16777215", want: "10.255.255.255/32"},
+		{snet: "2001:db8::/32", bits: 64, n: "65537", want: "2001:db8:1:1::/64"},
+		{snet: "::/0", bits: 128, n: "340282366920938463463374607431768211455", want: "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff/128"},
+	}
+
+	for _, test := range childTests {
+		s, _ := ParseCIDR(test.snet)
+		n, _ := new(big.Int).SetString(test.n, 10)
+		c, err := s.Child(test.bits, n)
+		if err != nil || c.String() != test.want {
+			t.Error("Error getting .Child() for", test.snet, "at", test.n, "Expected:", test.want, "Got:", c.String(), "With Error:", err)
+		}
+	}
+
+	s, _ := ParseCIDR("10.0.0.0/16")
+	if _, err := s.Child(24, big.NewInt(256)); err == nil {
+		t.Error("Error getting .Child() past the end, Expected an error")
+	}
+}
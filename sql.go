@@ -0,0 +1,113 @@
+package netmath
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// Scan a PostgreSQL inet or cidr value, where an inet without a prefix length is a single host ex. 10.1.1.5 -> 10.1.1.5/32.
+// Use NullSubnet for nullable columns.
+func (s *Subnet) Scan(src any) error {
+	var str string
+	switch v := src.(type) {
+	case string:
+		str = v
+	case []byte:
+		str = string(v)
+	case nil:
+		return fmt.Errorf("cannot scan NULL into Subnet, use NullSubnet")
+	default:
+		return fmt.Errorf("cannot scan %T into Subnet", src)
+	}
+
+	if !strings.Contains(str, "/") {
+		addr, err := netip.ParseAddr(str)
+		if err != nil {
+			offset, reason := locateAddrError(str)
+			return newParseError(str, offset, ErrInvalidAddr, reason)
+		}
+		*s = NewSubnet(netip.PrefixFrom(addr, addr.BitLen()))
+		return nil
+	}
+
+	snet, err := ParseCIDR(str)
+	if err != nil {
+		return err
+	}
+	*s = snet
+	return nil
+}
+
+// Store the subnet in the <ip-address>/<bits> format, keeping host bits.
+// Canonical subnets suit both inet and cidr columns, PostgreSQL rejects host bits in a cidr column.
+func (s Subnet) Value() (driver.Value, error) {
+	if !s.IsValid() {
+		return nil, ErrInvalidPrefix
+	}
+	return s.String(), nil
+}
+
+// Get the PostgreSQL inet text form, which omits the prefix length of a single host ex. 10.1.1.5/32 -> 10.1.1.5
+func (s Subnet) Inet() string {
+	if s.IsSingleIP() {
+		return s.Addr().String()
+	}
+	return s.String()
+}
+
+// Get the PostgreSQL cidr text form, which has no host bits ex. 10.1.1.5/24 -> 10.1.1.0/24
+func (s Subnet) CIDR() string {
+	return s.Masked().String()
+}
+
+// Subnet that may be NULL, in the style of sql.NullString
+type NullSubnet struct {
+	Subnet Subnet
+	Valid  bool // Valid is true if Subnet is not NULL
+}
+
+// Scan a nullable PostgreSQL inet or cidr value
+func (n *NullSubnet) Scan(src any) error {
+	if src == nil {
+		n.Subnet, n.Valid = Subnet{}, false
+		return nil
+	}
+
+	if err := n.Subnet.Scan(src); err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Store the subnet, or NULL when it is not valid
+func (n NullSubnet) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Subnet.Value()
+}
+
+// Check if the subnet is strictly inside o, the PostgreSQL << operator ex. 10.1.0.0/16 << 10.0.0.0/8
+func (s Subnet) IsContainedBy(o Subnet) bool {
+	return s.IsContainedByOrEqual(o) && s.Bits() > o.Bits()
+}
+
+// Check if the subnet is inside or equal to o, the PostgreSQL <<= operator
+func (s Subnet) IsContainedByOrEqual(o Subnet) bool {
+	return s.IsValid() && o.IsValid() && s.Addr().Is4() == o.Addr().Is4() &&
+		s.Bits() >= o.Bits() && o.Masked().Contains(s.Addr())
+}
+
+// Check if the subnet strictly contains o, the PostgreSQL >> operator ex. 10.0.0.0/8 >> 10.1.0.0/16
+func (s Subnet) ContainsSubnet(o Subnet) bool {
+	return o.IsContainedBy(s)
+}
+
+// Check if the subnet contains or equals o, the PostgreSQL >>= operator
+func (s Subnet) ContainsOrEqualSubnet(o Subnet) bool {
+	return o.IsContainedByOrEqual(s)
+}
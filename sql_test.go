@@ -0,0 +1,102 @@
+package netmath
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestScan(t *testing.T) {
+	scanTests := []struct {
+		src    any
+		result string
+		inet   string
+		cidr   string
+		err    error
+	}{
+		{src: "192.168.1.5", result: "192.168.1.5/32", inet: "192.168.1.5", cidr: "192.168.1.5/32"},
+		{src: "192.168.1.5/24", result: "192.168.1.5/24", inet: "192.168.1.5/24", cidr: "192.168.1.0/24"},
+		{src: []byte("10.0.0.0/8"), result: "10.0.0.0/8", inet: "10.0.0.0/8", cidr: "10.0.0.0/8"},
+		{src: "2001:db8::1", result: "2001:db8::1/128", inet: "2001:db8::1", cidr: "2001:db8::1/128"},
+		{src: "2001:db8::1/64", result: "2001:db8::1/64", inet: "2001:db8::1/64", cidr: "2001:db8::/64"},
+		{src: "192.168.1.256", err: ErrOctetOutOfRange},
+		{src: "192.168.1.0/33", err: ErrBitsOutOfRange},
+	}
+
+	for _, test := range scanTests {
+		var s Subnet
+		err := s.Scan(test.src)
+		if test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Error("Error getting .Scan() for", test.src, "Expected:", test.err, "Got:", err)
+			}
+			continue
+		}
+		if err != nil || s.String() != test.result || s.Inet() != test.inet || s.CIDR() != test.cidr {
+			t.Error("Error getting .Scan() for", test.src, "Expected:", test.result, test.inet, test.cidr, "Got:", s, s.Inet(), s.CIDR(), err)
+		}
+
+		v, err := s.Value()
+		if err != nil || v != test.result {
+			t.Error("Error getting .Value() for", test.src, "Expected:", test.result, "Got:", v, err)
+		}
+	}
+
+	var s Subnet
+	if err := s.Scan(nil); err == nil {
+		t.Error("Error getting .Scan() for NULL", "Expected an error Got:", err)
+	}
+	if err := s.Scan(42); err == nil {
+		t.Error("Error getting .Scan() for an int", "Expected an error Got:", err)
+	}
+	if _, err := (Subnet{}).Value(); err == nil {
+		t.Error("Error getting .Value() for the zero Subnet", "Expected an error Got:", err)
+	}
+}
+
+func TestNullSubnet(t *testing.T) {
+	var n NullSubnet
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Error("Error getting .Scan() for NULL", "Expected: invalid <nil> Got:", n.Valid, err)
+	}
+	if v, err := n.Value(); err != nil || v != nil {
+		t.Error("Error getting .Value() for NULL", "Expected: <nil> <nil> Got:", v, err)
+	}
+
+	if err := n.Scan("10.0.0.0/8"); err != nil || !n.Valid || n.Subnet.String() != "10.0.0.0/8" {
+		t.Error("Error getting .Scan() for 10.0.0.0/8", "Expected: 10.0.0.0/8 valid Got:", n.Subnet, n.Valid, err)
+	}
+	if v, err := n.Value(); err != nil || v != "10.0.0.0/8" {
+		t.Error("Error getting .Value() for 10.0.0.0/8", "Expected: 10.0.0.0/8 Got:", v, err)
+	}
+
+	if err := n.Scan("bad"); err == nil || n.Valid {
+		t.Error("Error getting .Scan() for bad", "Expected an error and invalid Got:", n.Valid, err)
+	}
+}
+
+func TestContainmentOperators(t *testing.T) {
+	operatorTests := []struct {
+		a, b string
+		// Results of a << b, a <<= b, a >> b and a >>= b
+		lt, le, gt, ge bool
+	}{
+		{a: "10.1.0.0/16", b: "10.0.0.0/8", lt: true, le: true},
+		{a: "10.0.0.0/8", b: "10.1.0.0/16", gt: true, ge: true},
+		{a: "10.0.0.0/8", b: "10.0.0.0/8", le: true, ge: true},
+		{a: "10.1.2.3/8", b: "10.0.0.0/8", le: true, ge: true},
+		{a: "192.168.1.5/32", b: "192.168.1.0/24", lt: true, le: true},
+		{a: "192.168.1.0/24", b: "192.168.2.0/24"},
+		{a: "10.0.0.0/8", b: "::/0"},
+		{a: "2001:db8:1::/48", b: "2001:db8::/32", lt: true, le: true},
+	}
+
+	for _, test := range operatorTests {
+		a, _ := ParseCIDR(test.a)
+		b, _ := ParseCIDR(test.b)
+		lt, le := a.IsContainedBy(b), a.IsContainedByOrEqual(b)
+		gt, ge := a.ContainsSubnet(b), a.ContainsOrEqualSubnet(b)
+		if lt != test.lt || le != test.le || gt != test.gt || ge != test.ge {
+			t.Error("Error comparing", test.a, "and", test.b, "Expected:", test.lt, test.le, test.gt, test.ge, "Got:", lt, le, gt, ge)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package netmath
+
+import (
+	"iter"
+	"net/netip"
+)
+
+// Routing table that maps subnets to values with longest-prefix-match lookups for IPv4 and IPv6.
+// Keys are stored by network address, so 10.0.0.7/8 and 10.0.0.0/8 are the same key.
+// The zero value is an empty table. A Table is not safe for concurrent writes.
+type Table[V any] struct {
+	v4   *tableNode[V]
+	v6   *tableNode[V]
+	size int
+}
+
+// Node of the binary trie, one level per prefix bit
+type tableNode[V any] struct {
+	children [2]*tableNode[V]
+	value    V
+	set      bool
+}
+
+func (t *Table[V]) root(is4 bool) **tableNode[V] {
+	if is4 {
+		return &t.v4
+	}
+	return &t.v6
+}
+
+// Insert or replace the value stored for the subnet
+func (t *Table[V]) Insert(s Subnet, v V) error {
+	if !s.IsValid() {
+		return ErrInvalidPrefix
+	}
+
+	is4, width := s.Addr().Is4(), s.Addr().BitLen()
+	key := addrToUint128(s.Addr())
+
+	node := t.root(is4)
+	for depth := 0; ; depth++ {
+		if *node == nil {
+			*node = &tableNode[V]{}
+		}
+		if depth == s.Bits() {
+			break
+		}
+		node = &(*node).children[key.bit(depth, width)]
+	}
+
+	if !(*node).set {
+		t.size++
+	}
+	(*node).value, (*node).set = v, true
+
+	return nil
+}
+
+// Remove the subnet from the table, reporting whether it was present
+func (t *Table[V]) Delete(s Subnet) bool {
+	if !s.IsValid() {
+		return false
+	}
+
+	is4, width := s.Addr().Is4(), s.Addr().BitLen()
+	key := addrToUint128(s.Addr())
+
+	// Remember the path so empty branches can be pruned afterwards
+	path := []**tableNode[V]{t.root(is4)}
+	for depth := 0; depth < s.Bits(); depth++ {
+		node := *path[len(path)-1]
+		if node == nil {
+			return false
+		}
+		path = append(path, &node.children[key.bit(depth, width)])
+	}
+
+	node := *path[len(path)-1]
+	if node == nil || !node.set {
+		return false
+	}
+
+	var zero V
+	node.value, node.set = zero, false
+	t.size--
+
+	for i := len(path) - 1; i >= 0; i-- {
+		n := *path[i]
+		if n.set || n.children[0] != nil || n.children[1] != nil {
+			break
+		}
+		*path[i] = nil
+	}
+
+	return true
+}
+
+// Get the value stored for exactly this subnet
+func (t *Table[V]) Get(s Subnet) (V, bool) {
+	var zero V
+	if !s.IsValid() {
+		return zero, false
+	}
+
+	width := s.Addr().BitLen()
+	key := addrToUint128(s.Addr())
+
+	node := *t.root(s.Addr().Is4())
+	for depth := 0; node != nil && depth < s.Bits(); depth++ {
+		node = node.children[key.bit(depth, width)]
+	}
+
+	if node == nil || !node.set {
+		return zero, false
+	}
+	return node.value, true
+}
+
+// Find the most specific subnet in the table that contains the address
+func (t *Table[V]) Lookup(addr netip.Addr) (Subnet, V, bool) {
+	var match Subnet
+	var value V
+	var found bool
+
+	if addr.IsValid() {
+		for s, v := range t.Covering(NewSubnet(netip.PrefixFrom(addr, addr.BitLen()))) {
+			match, value, found = s, v, true
+		}
+	}
+
+	return match, value, found
+}
+
+// Iterate over the subnets in the table that contain s, including s itself, from least to most specific
+func (t *Table[V]) Covering(s Subnet) iter.Seq2[Subnet, V] {
+	return func(yield func(Subnet, V) bool) {
+		if !s.IsValid() {
+			return
+		}
+
+		is4, width := s.Addr().Is4(), s.Addr().BitLen()
+		key := addrToUint128(s.Addr())
+
+		node := *t.root(is4)
+		for depth := 0; node != nil; depth++ {
+			if node.set {
+				p := netip.PrefixFrom(key.addr(is4), depth).Masked()
+				if !yield(NewSubnet(p), node.value) {
+					return
+				}
+			}
+			if depth == s.Bits() {
+				return
+			}
+			node = node.children[key.bit(depth, width)]
+		}
+	}
+}
+
+// Iterate over the subnets in the table that are inside s, including s itself, in address order
+func (t *Table[V]) Covered(s Subnet) iter.Seq2[Subnet, V] {
+	return func(yield func(Subnet, V) bool) {
+		if !s.IsValid() {
+			return
+		}
+
+		is4, width := s.Addr().Is4(), s.Addr().BitLen()
+		key := addrToUint128(s.Masked().Addr())
+
+		node := *t.root(is4)
+		for depth := 0; node != nil && depth < s.Bits(); depth++ {
+			node = node.children[key.bit(depth, width)]
+		}
+
+		walkTable(node, key, s.Bits(), is4, yield)
+	}
+}
+
+// Iterate over every subnet in the table, IPv4 first, in address order
+func (t *Table[V]) All() iter.Seq2[Subnet, V] {
+	return func(yield func(Subnet, V) bool) {
+		if walkTable(t.v4, uint128{}, 0, true, yield) {
+			walkTable(t.v6, uint128{}, 0, false, yield)
+		}
+	}
+}
+
+// Number of subnets in the table
+func (t *Table[V]) Len() int {
+	return t.size
+}
+
+// Walk the trie depth first, returning false once yield asks to stop
+func walkTable[V any](node *tableNode[V], key uint128, depth int, is4 bool, yield func(Subnet, V) bool) bool {
+	if node == nil {
+		return true
+	}
+
+	if node.set && !yield(NewSubnet(netip.PrefixFrom(key.addr(is4), depth)), node.value) {
+		return false
+	}
+
+	width := 128
+	if is4 {
+		width = 32
+	}
+
+	if !walkTable(node.children[0], key, depth+1, is4, yield) {
+		return false
+	}
+	one := uint128{lo: 1}.lsh(uint(width - 1 - depth))
+	return walkTable(node.children[1], key.or(one), depth+1, is4, yield)
+}
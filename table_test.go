@@ -0,0 +1,141 @@
+package netmath
+
+import (
+	"net/netip"
+	"slices"
+	"testing"
+)
+
+func newTestTable(t *testing.T) *Table[string] {
+	t.Helper()
+
+	var table Table[string]
+	for _, str := range []string{"0.0.0.0/0", "10.0.0.0/8", "10.1.0.0/16", "10.1.2.0/24", "192.168.0.0/16", "2001:db8::/32", "2001:db8:1::/48"} {
+		s, _ := ParseCIDR(str)
+		if err := table.Insert(s, str); err != nil {
+			t.Fatal("Error inserting", str, "Error:", err)
+		}
+	}
+	return &table
+}
+
+func TestTableLookup(t *testing.T) {
+	table := newTestTable(t)
+
+	lookupTests := []struct {
+		addr string
+		want string
+	}{
+		{addr: "10.1.2.3", want: "10.1.2.0/24"},
+		{addr: "10.1.3.3", want: "10.1.0.0/16"},
+		{addr: "10.2.0.1", want: "10.0.0.0/8"},
+		{addr: "8.8.8.8", want: "0.0.0.0/0"},
+		{addr: "192.168.255.255", want: "192.168.0.0/16"},
+		{addr: "2001:db8:1::1", want: "2001:db8:1::/48"},
+		{addr: "2001:db8:2::1", want: "2001:db8::/32"},
+		{addr: "2001:db9::1", want: ""},
+	}
+
+	for _, test := range lookupTests {
+		s, v, ok := table.Lookup(netip.MustParseAddr(test.addr))
+		if test.want == "" {
+			if ok {
+				t.Error("Error getting .Lookup() for", test.addr, "Expected no match Got:", s)
+			}
+			continue
+		}
+		if !ok || s.String() != test.want || v != test.want {
+			t.Error("Error getting .Lookup() for", test.addr, "Expected:", test.want, "Got:", s, v)
+		}
+	}
+}
+
+func TestTableGetDelete(t *testing.T) {
+	table := newTestTable(t)
+
+	if table.Len() != 7 {
+		t.Error("Error getting .Len() Expected: 7 Got:", table.Len())
+	}
+
+	s, _ := ParseCIDR("10.1.77.77/16")
+	if v, ok := table.Get(s); !ok || v != "10.1.0.0/16" {
+		t.Error("Error getting .Get() for", s, "Got:", v, ok)
+	}
+
+	missing, _ := ParseCIDR("10.1.0.0/17")
+	if _, ok := table.Get(missing); ok {
+		t.Error("Error getting .Get() for", missing, "Expected no match")
+	}
+	if table.Delete(missing) {
+		t.Error("Error getting .Delete() for", missing, "Expected false")
+	}
+
+	if !table.Delete(s) {
+		t.Error("Error getting .Delete() for", s, "Expected true")
+	}
+	if _, ok := table.Get(s); ok {
+		t.Error("Error getting .Get() for deleted", s)
+	}
+	if got, _, _ := table.Lookup(netip.MustParseAddr("10.1.3.3")); got.String() != "10.0.0.0/8" {
+		t.Error("Error getting .Lookup() after delete, Expected: 10.0.0.0/8 Got:", got)
+	}
+	if got, _, _ := table.Lookup(netip.MustParseAddr("10.1.2.3")); got.String() != "10.1.2.0/24" {
+		t.Error("Error getting .Lookup() after delete, Expected: 10.1.2.0/24 Got:", got)
+	}
+	if table.Len() != 6 {
+		t.Error("Error getting .Len() after delete, Expected: 6 Got:", table.Len())
+	}
+
+	// Replacing a value keeps the size
+	table.Insert(s, "again")
+	table.Insert(s, "replaced")
+	if v, _ := table.Get(s); v != "replaced" || table.Len() != 7 {
+		t.Error("Error replacing", s, "Got:", v, "with length", table.Len())
+	}
+}
+
+func TestTableCovering(t *testing.T) {
+	table := newTestTable(t)
+
+	coverTests := []struct {
+		snet     string
+		covering []string
+		covered  []string
+	}{
+		{snet: "10.1.2.128/25", covering: []string{"0.0.0.0/0", "10.0.0.0/8", "10.1.0.0/16", "10.1.2.0/24"}, covered: nil},
+		{snet: "10.0.0.0/8", covering: []string{"0.0.0.0/0", "10.0.0.0/8"}, covered: []string{"10.0.0.0/8", "10.1.0.0/16", "10.1.2.0/24"}},
+		{snet: "0.0.0.0/0", covering: []string{"0.0.0.0/0"}, covered: []string{"0.0.0.0/0", "10.0.0.0/8", "10.1.0.0/16", "10.1.2.0/24", "192.168.0.0/16"}},
+		{snet: "2001:db8::/16", covering: nil, covered: []string{"2001:db8::/32", "2001:db8:1::/48"}},
+	}
+
+	for _, test := range coverTests {
+		s, _ := ParseCIDR(test.snet)
+
+		var covering, covered []string
+		for k := range table.Covering(s) {
+			covering = append(covering, k.String())
+		}
+		for k := range table.Covered(s) {
+			covered = append(covered, k.String())
+		}
+
+		if !slices.Equal(covering, test.covering) {
+			t.Error("Error getting .Covering() for", test.snet, "Expected:", test.covering, "Got:", covering)
+		}
+		if !slices.Equal(covered, test.covered) {
+			t.Error("Error getting .Covered() for", test.snet, "Expected:", test.covered, "Got:", covered)
+		}
+	}
+
+	var all []string
+	for k, v := range table.All() {
+		if k.String() != v {
+			t.Error("Error getting .All() Expected value:", k, "Got:", v)
+		}
+		all = append(all, k.String())
+	}
+	want := []string{"0.0.0.0/0", "10.0.0.0/8", "10.1.0.0/16", "10.1.2.0/24", "192.168.0.0/16", "2001:db8::/32", "2001:db8:1::/48"}
+	if !slices.Equal(all, want) {
+		t.Error("Error getting .All() Expected:", want, "Got:", all)
+	}
+}
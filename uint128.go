@@ -0,0 +1,157 @@
+package netmath
+
+import (
+	"encoding/binary"
+	"math/big"
+	"math/bits"
+	"net/netip"
+)
+
+// Unsigned 128 bit integer used for address arithmetic. IPv4 addresses occupy the low 32 bits.
+type uint128 struct {
+	hi uint64
+	lo uint64
+}
+
+func addrToUint128(addr netip.Addr) uint128 {
+	if addr.Is4() {
+		b := addr.As4()
+		return uint128{lo: uint64(binary.BigEndian.Uint32(b[:]))}
+	}
+	b := addr.As16()
+	return uint128{hi: binary.BigEndian.Uint64(b[:8]), lo: binary.BigEndian.Uint64(b[8:])}
+}
+
+func (u uint128) addr(is4 bool) netip.Addr {
+	if is4 {
+		var b [4]byte
+		binary.BigEndian.PutUint32(b[:], uint32(u.lo))
+		return netip.AddrFrom4(b)
+	}
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], u.hi)
+	binary.BigEndian.PutUint64(b[8:], u.lo)
+	return netip.AddrFrom16(b)
+}
+
+func (u uint128) isZero() bool {
+	return u.hi == 0 && u.lo == 0
+}
+
+func (u uint128) cmp(v uint128) int {
+	switch {
+	case u.hi < v.hi:
+		return -1
+	case u.hi > v.hi:
+		return 1
+	case u.lo < v.lo:
+		return -1
+	case u.lo > v.lo:
+		return 1
+	}
+	return 0
+}
+
+// Addition wraps around on overflow
+func (u uint128) add(v uint128) uint128 {
+	lo, carry := bits.Add64(u.lo, v.lo, 0)
+	hi, _ := bits.Add64(u.hi, v.hi, carry)
+	return uint128{hi: hi, lo: lo}
+}
+
+// Subtraction wraps around on underflow
+func (u uint128) sub(v uint128) uint128 {
+	lo, borrow := bits.Sub64(u.lo, v.lo, 0)
+	hi, _ := bits.Sub64(u.hi, v.hi, borrow)
+	return uint128{hi: hi, lo: lo}
+}
+
+func (u uint128) addOne() uint128 {
+	return u.add(uint128{lo: 1})
+}
+
+func (u uint128) subOne() uint128 {
+	return u.sub(uint128{lo: 1})
+}
+
+func (u uint128) and(v uint128) uint128 {
+	return uint128{hi: u.hi & v.hi, lo: u.lo & v.lo}
+}
+
+func (u uint128) or(v uint128) uint128 {
+	return uint128{hi: u.hi | v.hi, lo: u.lo | v.lo}
+}
+
+func (u uint128) xor(v uint128) uint128 {
+	return uint128{hi: u.hi ^ v.hi, lo: u.lo ^ v.lo}
+}
+
+func (u uint128) not() uint128 {
+	return uint128{hi: ^u.hi, lo: ^u.lo}
+}
+
+func (u uint128) lsh(n uint) uint128 {
+	switch {
+	case n >= 128:
+		return uint128{}
+	case n >= 64:
+		return uint128{hi: u.lo << (n - 64)}
+	case n == 0:
+		return u
+	}
+	return uint128{hi: u.hi<<n | u.lo>>(64-n), lo: u.lo << n}
+}
+
+func (u uint128) rsh(n uint) uint128 {
+	switch {
+	case n >= 128:
+		return uint128{}
+	case n >= 64:
+		return uint128{lo: u.hi >> (n - 64)}
+	case n == 0:
+		return u
+	}
+	return uint128{hi: u.hi >> n, lo: u.lo>>n | u.hi<<(64-n)}
+}
+
+// Value of the bit at position i counting from the most significant bit of a width bit number
+func (u uint128) bit(i int, width int) uint {
+	return uint(u.rsh(uint(width-1-i)).lo & 1)
+}
+
+// Number of trailing zero bits, 128 for zero
+func (u uint128) trailingZeros() int {
+	if u.lo != 0 {
+		return bits.TrailingZeros64(u.lo)
+	}
+	return 64 + bits.TrailingZeros64(u.hi)
+}
+
+// Number of bits needed to represent the value
+func (u uint128) bitLen() int {
+	if u.hi != 0 {
+		return 64 + bits.Len64(u.hi)
+	}
+	return bits.Len64(u.lo)
+}
+
+func (u uint128) big() *big.Int {
+	b := new(big.Int).SetUint64(u.hi)
+	b.Lsh(b, 64)
+	return b.Or(b, new(big.Int).SetUint64(u.lo))
+}
+
+// Convert a non-negative big.Int that fits in 128 bits
+func uint128FromBig(b *big.Int) (uint128, bool) {
+	if b.Sign() < 0 || b.BitLen() > 128 {
+		return uint128{}, false
+	}
+	lo := new(big.Int).And(b, new(big.Int).SetUint64(^uint64(0)))
+	hi := new(big.Int).Rsh(b, 64)
+	return uint128{hi: hi.Uint64(), lo: lo.Uint64()}, true
+}
+
+// Mask with the host bits of a width bit address set for the given prefix length
+func hostMask(bits int, width int) uint128 {
+	return uint128{hi: ^uint64(0), lo: ^uint64(0)}.rsh(uint(128 - width + bits))
+}
@@ -0,0 +1,80 @@
+package netmath
+
+import (
+	"crypto/sha1"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"iter"
+	"math/big"
+	"net"
+	"net/netip"
+	"time"
+)
+
+// Seconds between the NTP epoch (1900) and the Unix epoch (1970)
+const ntpEpochOffset = 2208988800
+
+// Generate an RFC 4193 Unique Local /48 from the SHA-1 digest of the NTP time and an EUI-64, as in section 3.2.2.
+// A 48 bit MAC is expanded to an EUI-64 by inserting ff:fe.
+func ULAFromTime(t time.Time, eui64 net.HardwareAddr) (Subnet, error) {
+	var key [16]byte
+	secs := uint64(t.Unix() + ntpEpochOffset)
+	frac := uint64(t.Nanosecond()) << 32 / uint64(time.Second)
+	binary.BigEndian.PutUint64(key[:8], secs<<32|frac)
+
+	switch len(eui64) {
+	case 6:
+		copy(key[8:11], eui64[:3])
+		key[11], key[12] = 0xff, 0xfe
+		copy(key[13:], eui64[3:])
+	case 8:
+		copy(key[8:], eui64)
+	default:
+		return Subnet{}, fmt.Errorf("invalid EUI-64 length %d", len(eui64))
+	}
+
+	sum := sha1.Sum(key[:])
+	// The global ID is the least significant 40 bits of the digest
+	return newULA([5]byte(sum[15:])), nil
+}
+
+// Generate an RFC 4193 Unique Local /48 with a global ID read from r ex. crypto/rand.Reader
+func ULAFromReader(r io.Reader) (Subnet, error) {
+	var id [5]byte
+	if _, err := io.ReadFull(r, id[:]); err != nil {
+		return Subnet{}, err
+	}
+	return newULA(id), nil
+}
+
+// Build fdXX:XXXX:XXXX::/48 from a 40 bit global ID
+func newULA(id [5]byte) Subnet {
+	var b [16]byte
+	b[0] = 0xfd // fc00::/7 with the L bit set
+	copy(b[1:6], id[:])
+	return NewSubnet(netip.PrefixFrom(netip.AddrFrom16(b), 48))
+}
+
+// Get the /64 with the given 16 bit subnet ID in a /48 site prefix ex. fd12:3456:789a::/48 at 0x10 -> fd12:3456:789a:10::/64
+func (s Subnet) SiteSubnet(id uint16) (Subnet, error) {
+	if err := checkSitePrefix(s); err != nil {
+		return Subnet{}, err
+	}
+	return s.Child(64, big.NewInt(int64(id)))
+}
+
+// Iterate over the 65536 /64 subnets of a /48 site prefix
+func (s Subnet) SiteSubnets(opts IterOptions) (iter.Seq[Subnet], error) {
+	if err := checkSitePrefix(s); err != nil {
+		return nil, err
+	}
+	return s.ChildSeq(64, opts)
+}
+
+func checkSitePrefix(s Subnet) error {
+	if !s.IsValid() || !s.Addr().Is6() || s.Bits() != 48 {
+		return fmt.Errorf("%w: site subnets need an IPv6 /48, got %s", ErrInvalidPrefix, s)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package netmath
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"math/big"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestULAFromTime(t *testing.T) {
+	eui64 := net.HardwareAddr{0x00, 0x11, 0x22, 0xff, 0xfe, 0x33, 0x44, 0x55}
+
+	// The Unix epoch is 0x83aa7e80 seconds into the NTP era with no fraction
+	sum := sha1.Sum([]byte("\x83\xaa\x7e\x80\x00\x00\x00\x00\x00\x11\x22\xff\xfe\x33\x44\x55"))
+	var b [16]byte
+	b[0] = 0xfd
+	copy(b[1:6], sum[15:])
+	expected := netip.PrefixFrom(netip.AddrFrom16(b), 48).String()
+
+	ula, err := ULAFromTime(time.Unix(0, 0), eui64)
+	if err != nil || ula.String() != expected {
+		t.Error("Error getting ULAFromTime()", "Expected:", expected, "Got:", ula, err)
+	}
+
+	mac := net.HardwareAddr{0x00, 0x11, 0x22, 0x33, 0x44, 0x55}
+	fromMAC, err := ULAFromTime(time.Unix(0, 0), mac)
+	if err != nil || fromMAC != ula {
+		t.Error("Error getting ULAFromTime() for a 48 bit MAC", "Expected:", ula, "Got:", fromMAC, err)
+	}
+
+	later, _ := ULAFromTime(time.Unix(0, 500), eui64)
+	if later == ula || !mustParseCIDR("fd00::/8").ContainsSubnet(later) {
+		t.Error("Error getting ULAFromTime() for a later time", "Expected a different fd00::/8 prefix Got:", later)
+	}
+
+	if _, err := ULAFromTime(time.Unix(0, 0), net.HardwareAddr{1, 2}); err == nil {
+		t.Error("Error getting ULAFromTime() for a 2 byte identifier", "Expected an error Got:", err)
+	}
+}
+
+func TestULAFromReader(t *testing.T) {
+	ula, err := ULAFromReader(bytes.NewReader([]byte{0x12, 0x34, 0x56, 0x78, 0x9a}))
+	if err != nil || ula.String() != "fd12:3456:789a::/48" {
+		t.Error("Error getting ULAFromReader()", "Expected:", "fd12:3456:789a::/48", "Got:", ula, err)
+	}
+
+	if _, err := ULAFromReader(bytes.NewReader([]byte{0x12, 0x34})); err == nil {
+		t.Error("Error getting ULAFromReader() for a short read", "Expected an error Got:", err)
+	}
+}
+
+func TestSiteSubnets(t *testing.T) {
+	site := mustParseCIDR("fd12:3456:789a::/48")
+
+	subnetTests := []struct {
+		id     uint16
+		result string
+	}{
+		{id: 0, result: "fd12:3456:789a::/64"},
+		{id: 0x10, result: "fd12:3456:789a:10::/64"},
+		{id: 0xffff, result: "fd12:3456:789a:ffff::/64"},
+	}
+
+	for _, test := range subnetTests {
+		s, err := site.SiteSubnet(test.id)
+		if err != nil || s.String() != test.result {
+			t.Error("Error getting .SiteSubnet() for", test.id, "Expected:", test.result, "Got:", s, err)
+		}
+	}
+
+	seq, err := site.SiteSubnets(IterOptions{Reverse: true, Offset: big.NewInt(1)})
+	if err != nil {
+		t.Fatal("Error getting .SiteSubnets() for", site, "Expected: <nil> Got:", err)
+	}
+	var result []string
+	for s := range seq {
+		result = append(result, s.String())
+		if len(result) == 2 {
+			break
+		}
+	}
+	if len(result) != 2 || result[0] != "fd12:3456:789a:fffe::/64" || result[1] != "fd12:3456:789a:fffd::/64" {
+		t.Error("Error getting .SiteSubnets() for", site, "Expected: [fd12:3456:789a:fffe::/64 fd12:3456:789a:fffd::/64] Got:", result)
+	}
+
+	for _, cidr := range []string{"fd12:3456::/32", "10.0.0.0/8"} {
+		s := NewSubnet(netip.MustParsePrefix(cidr))
+		if _, err := s.SiteSubnet(1); err == nil {
+			t.Error("Error getting .SiteSubnet() for", cidr, "Expected an error Got:", err)
+		}
+		if _, err := s.SiteSubnets(IterOptions{}); err == nil {
+			t.Error("Error getting .SiteSubnets() for", cidr, "Expected an error Got:", err)
+		}
+	}
+}
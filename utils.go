@@ -8,7 +8,7 @@ import (
 
 func bitsToMask(bits int, is4 bool) (netip.Addr, error) {
 	if bits < 0 || bits > 128 {
-		return netip.IPv4Unspecified(), fmt.Errorf("invalid bit length")
+		return netip.IPv4Unspecified(), fmt.Errorf("%w: %d", ErrInvalidBits, bits)
 	}
 
 	var ip net.IP
@@ -25,20 +25,31 @@ func bitsToMask(bits int, is4 bool) (netip.Addr, error) {
 	return addr, nil
 }
 
+func getHostBits(addr netip.Addr, bits int) (int, error) {
+	if bits < 0 || bits > 128 {
+		return 0, fmt.Errorf("%w: %d", ErrInvalidBits, bits)
+	}
+
+	if addr.Is4() {
+		return 32 - bits, nil
+	} else if addr.Is6() {
+		return 128 - bits, nil
+	}
+	return 0, ErrInvalidAddr
+}
+
 func maskToBits(mask netip.Addr) (int, error) {
-	maskBytes := mask.AsSlice()
+	if bit := nonContiguousBit(mask); bit >= 0 {
+		s := mask.String()
+		return 0, newParseError(s, locateBit(s, bit), ErrInvalidMask, ErrNonContiguousMask)
+	}
 
 	bits := 0
-	ended := false
-	for _, b := range maskBytes {
+	for _, b := range mask.AsSlice() {
 		// Count the number of set bits in each byte
 		for m := byte(0x80); m != 0; m >>= 1 {
-			if b&m != 0 && ended {
-				return 0, fmt.Errorf("invalid subnet mask")
-			} else if b&m != 0 {
+			if b&m != 0 {
 				bits++
-			} else {
-				ended = true
 			}
 		}
 	}
@@ -46,6 +57,21 @@ func maskToBits(mask netip.Addr) (int, error) {
 	return bits, nil
 }
 
+// Index of the first set bit that follows a cleared bit, -1 for a contiguous mask
+func nonContiguousBit(mask netip.Addr) int {
+	ended := false
+	for i, b := range mask.AsSlice() {
+		for j, m := 0, byte(0x80); m != 0; j, m = j+1, m>>1 {
+			if b&m == 0 {
+				ended = true
+			} else if ended {
+				return i*8 + j
+			}
+		}
+	}
+	return -1
+}
+
 func getNetworkAddrBytes(ipBytes []byte, maskBytes []byte) []byte {
 	naBytes := make([]byte, len(ipBytes))
 	for i := range ipBytes {
@@ -64,21 +90,28 @@ func getBroadcastAddrBytes(ipBytes []byte, maskBytes []byte) []byte {
 	return baBytes
 }
 
-func fillEmptyBytes(b []byte, ipv4 bool) []byte {
-	if len(b) > 16 {
-		return b
+// First and last host address of a prefix. With usable set, the addresses excluded by CountUsable are trimmed.
+func getHostBounds(addr netip.Addr, bits int, usable bool) (uint128, uint128, error) {
+	hostBits, err := getHostBits(addr, bits)
+	if err != nil {
+		return uint128{}, uint128{}, err
 	}
 
-	var size int
-	if ipv4 {
-		size = 4
-	} else {
-		size = 16
-	}
+	mask := hostMask(bits, addr.BitLen())
+	first := addrToUint128(addr).and(mask.not())
+	last := first.or(mask)
 
-	for i := len(b); i < size; i++ {
-		b = append(b, byte(0))
+	if usable && hostBits > 1 {
+		first = first.addOne()
+		if addr.Is4() {
+			last = last.subOne()
+		}
 	}
 
-	return b
+	return first, last, nil
+}
+
+// Flip every bit of the address
+func invertAddr(addr netip.Addr) netip.Addr {
+	return addrToUint128(addr).not().and(hostMask(0, addr.BitLen())).addr(addr.Is4())
 }
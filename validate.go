@@ -0,0 +1,59 @@
+package netmath
+
+import (
+	"fmt"
+	"strings"
+)
+
+// How a prefix with host bits set is handled ex. 192.168.20.15/23
+type PrefixMode int
+
+const (
+	PrefixPreserve     PrefixMode = iota // Keep the host bits ex. 192.168.20.15/23 -> 192.168.20.15/23
+	PrefixCanonicalize                   // Clear the host bits ex. 192.168.20.15/23 -> 192.168.20.0/23
+	PrefixStrict                         // Reject the prefix with ErrHostBitsSet
+)
+
+// Parse an abbreviated IP and Subnet mask in the <ip-address>/<bits> format, handling host bits according to mode.
+// Failures are reported as a *ParseError.
+func ParseCIDRMode(s string, mode PrefixMode) (Subnet, error) {
+	snet, err := ParseCIDR(s)
+	if err != nil {
+		return Subnet{}, err
+	}
+	return snet.validate(mode, s)
+}
+
+// Check if the subnet has no host bits set, so it equals its network address
+func (s Subnet) IsCanonical() bool {
+	return s.IsValid() && s.Masked() == s.Prefix
+}
+
+// Apply the host bit handling of mode to the subnet ex. Strict fails for 192.168.20.15/23
+func (s Subnet) Validate(mode PrefixMode) (Subnet, error) {
+	if !s.IsValid() {
+		return Subnet{}, ErrInvalidPrefix
+	}
+	return s.validate(mode, s.String())
+}
+
+// Apply mode, reporting host bits against input written in the <ip-address>/<bits> format
+func (s Subnet) validate(mode PrefixMode, input string) (Subnet, error) {
+	switch mode {
+	case PrefixPreserve:
+		return s, nil
+	case PrefixCanonicalize:
+		return NewSubnet(s.Masked()), nil
+	case PrefixStrict:
+		if s.IsCanonical() {
+			return s, nil
+		}
+		// Point at the first address field that holds a host bit
+		width := s.Addr().BitLen()
+		hostBits := addrToUint128(s.Addr()).and(hostMask(s.Bits(), width))
+		addrStr, _, _ := strings.Cut(input, "/")
+
+		return Subnet{}, newParseError(input, locateBit(addrStr, width-hostBits.bitLen()), ErrInvalidPrefix, ErrHostBitsSet)
+	}
+	return Subnet{}, fmt.Errorf("unknown prefix mode %d", mode)
+}
@@ -0,0 +1,79 @@
+package netmath
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseCIDRMode(t *testing.T) {
+	modeTests := []struct {
+		cidr   string
+		mode   PrefixMode
+		result string
+		offset int
+		err    error
+	}{
+		{cidr: "192.168.20.15/23", mode: PrefixPreserve, result: "192.168.20.15/23"},
+		{cidr: "192.168.20.15/23", mode: PrefixCanonicalize, result: "192.168.20.0/23"},
+		{cidr: "192.168.20.15/23", mode: PrefixStrict, offset: 11, err: ErrHostBitsSet},
+		{cidr: "192.168.21.0/23", mode: PrefixStrict, offset: 8, err: ErrHostBitsSet},
+		{cidr: "192.168.20.0/23", mode: PrefixStrict, result: "192.168.20.0/23"},
+		{cidr: "10.0.0.1/32", mode: PrefixStrict, result: "10.0.0.1/32"},
+		{cidr: "2001:db8::1/64", mode: PrefixStrict, offset: 10, err: ErrHostBitsSet},
+		{cidr: "2001:db8::1/64", mode: PrefixCanonicalize, result: "2001:db8::/64"},
+		{cidr: "2001:db8::/64", mode: PrefixStrict, result: "2001:db8::/64"},
+		{cidr: "192.168.20.256/23", mode: PrefixStrict, offset: 11, err: ErrOctetOutOfRange},
+	}
+
+	for _, test := range modeTests {
+		s, err := ParseCIDRMode(test.cidr, test.mode)
+		if test.err != nil {
+			var pe *ParseError
+			if !errors.As(err, &pe) || !errors.Is(err, test.err) || pe.Offset != test.offset {
+				t.Error("Error getting ParseCIDRMode() for", test.cidr, "Expected:", test.err, "at offset", test.offset, "Got:", err)
+			}
+			continue
+		}
+		if err != nil || s.String() != test.result {
+			t.Error("Error getting ParseCIDRMode() for", test.cidr, "Expected:", test.result, "Got:", s, err)
+		}
+	}
+
+	snet, _ := ParseCIDR("192.168.20.0/23")
+	if _, err := snet.Validate(PrefixMode(99)); err == nil {
+		t.Error("Error getting .Validate() for an unknown mode", "Expected an error")
+	}
+	if _, err := ParseCIDRMode("192.168.20.0/23", PrefixMode(99)); err == nil {
+		t.Error("Error getting ParseCIDRMode() for an unknown mode", "Expected an error")
+	}
+}
+
+func TestIsCanonical(t *testing.T) {
+	canonicalTests := []struct {
+		cidr      string
+		canonical bool
+	}{
+		{cidr: "192.168.20.0/23", canonical: true},
+		{cidr: "192.168.20.15/23", canonical: false},
+		{cidr: "0.0.0.0/0", canonical: true},
+		{cidr: "10.1.1.1/32", canonical: true},
+		{cidr: "2001:db8::/32", canonical: true},
+		{cidr: "2001:db8::1/32", canonical: false},
+	}
+
+	for _, test := range canonicalTests {
+		s, _ := ParseCIDR(test.cidr)
+		if s.IsCanonical() != test.canonical {
+			t.Error("Error getting .IsCanonical() for", test.cidr, "Expected:", test.canonical, "Got:", s.IsCanonical())
+		}
+
+		_, err := s.Validate(PrefixStrict)
+		if (err == nil) != test.canonical {
+			t.Error("Error getting .Validate() for", test.cidr, "Expected canonical:", test.canonical, "Got:", err)
+		}
+	}
+
+	if (Subnet{}).IsCanonical() {
+		t.Error("Error getting .IsCanonical() for the zero Subnet", "Expected:", false, "Got:", true)
+	}
+}
@@ -0,0 +1,157 @@
+package netmath
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+)
+
+// Named number of hosts that a subnet must hold
+type HostRequirement struct {
+	Name  string
+	Hosts uint64
+	// Number of subnets with this requirement, 0 is treated as 1 and a negative count is an error.
+	// Repeated subnets are named <name>-<n>.
+	Count int
+}
+
+// Subnet allocated by PlanVLSM along with its addressing details
+type PlannedSubnet struct {
+	Name      string
+	Hosts     uint64 // Requested number of hosts
+	Subnet    Subnet
+	Network   netip.Addr
+	Broadcast netip.Addr
+	Mask      netip.Addr
+	Usable    IPRange
+}
+
+// Result of PlanVLSM
+type VLSMPlan struct {
+	Subnets []PlannedSubnet // Sorted largest first
+	Free    []Subnet        // Minimal list of unallocated space left in the parent
+}
+
+// Allocate the smallest subnet that fits each requirement from the parent, largest first
+// ex. 192.168.0.0/24 with [office: 100, dmz: 25] -> [office 192.168.0.0/25, dmz 192.168.0.128/27]
+func PlanVLSM(parent Subnet, reqs []HostRequirement) (VLSMPlan, error) {
+	if !parent.IsValid() {
+		return VLSMPlan{}, ErrInvalidPrefix
+	}
+	parent = NewSubnet(parent.Masked())
+
+	is4, width := parent.Addr().Is4(), parent.Addr().BitLen()
+
+	type request struct {
+		name  string
+		hosts uint64
+		bits  int
+	}
+
+	var requests []request
+	for _, req := range reqs {
+		if req.Count < 0 {
+			return VLSMPlan{}, fmt.Errorf("%s: %w %d", req.Name, ErrInvalidCount, req.Count)
+		}
+
+		bits, err := getBitsForHosts(req.Hosts, is4, width)
+		if err != nil {
+			return VLSMPlan{}, fmt.Errorf("%s: %w", req.Name, err)
+		}
+
+		count := max(req.Count, 1)
+		for i := 0; i < count; i++ {
+			name := req.Name
+			if count > 1 {
+				name = fmt.Sprintf("%s-%d", req.Name, i+1)
+			}
+			requests = append(requests, request{name: name, hosts: req.Hosts, bits: bits})
+		}
+	}
+
+	// Placing the largest subnets first keeps every following subnet aligned without gaps
+	slices.SortStableFunc(requests, func(a, b request) int {
+		return a.bits - b.bits
+	})
+
+	bounds, _ := subnetSpan(parent)
+	next := bounds.from
+	full := false
+
+	var plan VLSMPlan
+	var used []Subnet
+	for _, req := range requests {
+		last := next.add(hostMask(req.bits, width))
+		if full || req.bits < parent.Bits() || last.cmp(bounds.to) > 0 {
+			return VLSMPlan{}, fmt.Errorf("%s: not enough space in %s", req.name, parent)
+		}
+
+		s := NewSubnet(netip.PrefixFrom(next.addr(is4), req.bits))
+		planned, err := newPlannedSubnet(req.name, req.hosts, s)
+		if err != nil {
+			return VLSMPlan{}, err
+		}
+		plan.Subnets = append(plan.Subnets, planned)
+		used = append(used, s)
+
+		full = last == bounds.to
+		next = last.addOne()
+	}
+
+	parentSet, _ := NewIPSet(parent)
+	usedSet, _ := NewIPSet(used...)
+	plan.Free = parentSet.Difference(usedSet).Prefixes()
+
+	return plan, nil
+}
+
+func newPlannedSubnet(name string, hosts uint64, s Subnet) (PlannedSubnet, error) {
+	na, err := s.Network()
+	if err != nil {
+		return PlannedSubnet{}, err
+	}
+
+	ba, err := s.Broadcast()
+	if err != nil {
+		return PlannedSubnet{}, err
+	}
+
+	mask, err := s.Mask()
+	if err != nil {
+		return PlannedSubnet{}, err
+	}
+
+	usable, err := s.UsableRange()
+	if err != nil {
+		return PlannedSubnet{}, err
+	}
+
+	return PlannedSubnet{Name: name, Hosts: hosts, Subnet: s, Network: na, Broadcast: ba, Mask: mask, Usable: usable}, nil
+}
+
+// Longest prefix length whose usable host count holds the requested number of hosts
+func getBitsForHosts(hosts uint64, is4 bool, width int) (int, error) {
+	if hosts == 0 {
+		return 0, fmt.Errorf("invalid host count")
+	}
+
+	for hostBits := 0; hostBits <= width; hostBits++ {
+		if hostBits >= 64 {
+			return width - hostBits, nil
+		}
+
+		usable := uint64(1) << hostBits
+		if hostBits > 1 {
+			if is4 {
+				usable -= 2
+			} else {
+				usable--
+			}
+		}
+		if usable >= hosts {
+			return width - hostBits, nil
+		}
+	}
+
+	return 0, fmt.Errorf("too many hosts")
+}
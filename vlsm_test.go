@@ -0,0 +1,93 @@
+package netmath
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestPlanVLSM(t *testing.T) {
+	parent, _ := ParseCIDR("192.168.0.0/23")
+	reqs := []HostRequirement{
+		{Name: "dmz", Hosts: 25},
+		{Name: "office", Hosts: 120},
+		{Name: "p2p", Hosts: 2, Count: 3},
+		{Name: "servers", Hosts: 60},
+	}
+
+	plan, err := PlanVLSM(parent, reqs)
+	if err != nil {
+		t.Fatal("Error planning", parent, "Error:", err)
+	}
+
+	want := []struct {
+		name    string
+		snet    string
+		mask    string
+		network string
+		bcast   string
+		usable  string
+	}{
+		{name: "office", snet: "192.168.0.0/25", mask: "255.255.255.128", network: "192.168.0.0", bcast: "192.168.0.127", usable: "192.168.0.1-192.168.0.126"},
+		{name: "servers", snet: "192.168.0.128/26", mask: "255.255.255.192", network: "192.168.0.128", bcast: "192.168.0.191", usable: "192.168.0.129-192.168.0.190"},
+		{name: "dmz", snet: "192.168.0.192/27", mask: "255.255.255.224", network: "192.168.0.192", bcast: "192.168.0.223", usable: "192.168.0.193-192.168.0.222"},
+		// Point-to-point links get a /31 (RFC 3021)
+		{name: "p2p-1", snet: "192.168.0.224/31", mask: "255.255.255.254", network: "192.168.0.224", bcast: "192.168.0.225", usable: "192.168.0.224-192.168.0.225"},
+		{name: "p2p-2", snet: "192.168.0.226/31", mask: "255.255.255.254", network: "192.168.0.226", bcast: "192.168.0.227", usable: "192.168.0.226-192.168.0.227"},
+		{name: "p2p-3", snet: "192.168.0.228/31", mask: "255.255.255.254", network: "192.168.0.228", bcast: "192.168.0.229", usable: "192.168.0.228-192.168.0.229"},
+	}
+
+	if len(plan.Subnets) != len(want) {
+		t.Fatal("Error planning", parent, "Expected", len(want), "subnets Got", len(plan.Subnets))
+	}
+	for i, w := range want {
+		got := plan.Subnets[i]
+		if got.Name != w.name || got.Subnet.String() != w.snet || got.Mask.String() != w.mask || got.Network.String() != w.network || got.Broadcast.String() != w.bcast || got.Usable.String() != w.usable {
+			t.Error("Error planning", w.name, "Expected:", w, "Got:", got)
+		}
+	}
+
+	wantFree := []string{"192.168.0.230/31", "192.168.0.232/29", "192.168.0.240/28", "192.168.1.0/24"}
+	if free := subnetStrings(plan.Free); !slices.Equal(free, wantFree) {
+		t.Error("Error getting free space for", parent, "Expected:", wantFree, "Got:", free)
+	}
+}
+
+func TestPlanVLSMIPv6(t *testing.T) {
+	parent, _ := ParseCIDR("2001:db8::/120")
+	plan, err := PlanVLSM(parent, []HostRequirement{{Name: "lan", Hosts: 127}, {Name: "link", Hosts: 2}})
+	if err != nil {
+		t.Fatal("Error planning", parent, "Error:", err)
+	}
+
+	got := subnetStrings([]Subnet{plan.Subnets[0].Subnet, plan.Subnets[1].Subnet})
+	want := []string{"2001:db8::/121", "2001:db8::80/127"}
+	if !slices.Equal(got, want) {
+		t.Error("Error planning", parent, "Expected:", want, "Got:", got)
+	}
+}
+
+func TestPlanVLSMInvalid(t *testing.T) {
+	invalidTests := []struct {
+		snet string
+		reqs []HostRequirement
+	}{
+		{snet: "192.168.0.0/24", reqs: []HostRequirement{{Name: "big", Hosts: 255}}},
+		{snet: "192.168.0.0/24", reqs: []HostRequirement{{Name: "a", Hosts: 126}, {Name: "b", Hosts: 126}, {Name: "c", Hosts: 1}}},
+		{snet: "192.168.0.0/24", reqs: []HostRequirement{{Name: "none", Hosts: 0}}},
+		{snet: "invalid", reqs: []HostRequirement{{Name: "a", Hosts: 1}}},
+		{snet: "192.168.0.0/24", reqs: []HostRequirement{{Name: "negative", Hosts: 10, Count: -1}}},
+	}
+
+	for _, test := range invalidTests {
+		parent, _ := ParseCIDR(test.snet)
+		if _, err := PlanVLSM(parent, test.reqs); err == nil {
+			t.Error("Error planning", test.snet, "with", test.reqs, "Expected an error")
+		}
+	}
+
+	parent, _ := ParseCIDR("192.168.0.0/24")
+	if _, err := PlanVLSM(parent, []HostRequirement{{Name: "negative", Hosts: 10, Count: -1}}); !errors.Is(err, ErrInvalidCount) {
+		t.Error("Error planning", parent, "with a negative count", "Expected:", ErrInvalidCount, "Got:", err)
+	}
+}
@@ -0,0 +1,123 @@
+package netmath
+
+import (
+	"math/big"
+	"net/netip"
+)
+
+// Address pattern from an ACL entry ex. 10.0.0.1 0.0.255.0.
+// Address bits are compared where the Wildcard bit is 0 and ignored where it is 1, so the wildcard does not have to be contiguous.
+type WildcardMask struct {
+	Addr     netip.Addr
+	Wildcard netip.Addr
+}
+
+// Get the Wildcard (inverse) mask of the network ex. 192.168.20.15/23 -> 0.0.1.255
+func (s Subnet) Wildcard() (netip.Addr, error) {
+	mask, err := s.Mask()
+	if err != nil {
+		return netip.IPv4Unspecified(), err
+	}
+
+	return invertAddr(mask), nil
+}
+
+// Parse an IP and contiguous Wildcard mask in the long <ip-address>, <wildcard-mask> format ex. 10.1.1.0, 0.0.0.255 -> 10.1.1.0/24
+func ParseWildcard(addrStr string, wildcardStr string) (Subnet, error) {
+	w, err := ParseWildcardMask(addrStr, wildcardStr)
+	if err != nil {
+		return Subnet{}, err
+	}
+
+	return w.subnet(wildcardStr)
+}
+
+// Parse an IP and any Wildcard mask, contiguous or not, in the long <ip-address>, <wildcard-mask> format
+func ParseWildcardMask(addrStr string, wildcardStr string) (WildcardMask, error) {
+	addr, err := netip.ParseAddr(addrStr)
+	if err != nil || addr.Zone() != "" {
+		offset, reason := locateAddrError(addrStr)
+		return WildcardMask{}, newParseError(addrStr, offset, ErrInvalidAddr, reason)
+	}
+
+	wildcard, err := netip.ParseAddr(wildcardStr)
+	if err != nil {
+		offset, reason := locateAddrError(wildcardStr)
+		return WildcardMask{}, newParseError(wildcardStr, offset, ErrInvalidWildcard, reason)
+	}
+	if wildcard.Is4() != addr.Is4() {
+		return WildcardMask{}, newParseError(wildcardStr, 0, ErrInvalidWildcard, ErrFamilyMismatch)
+	}
+
+	return WildcardMask{Addr: addr, Wildcard: wildcard}, nil
+}
+
+// Check if the address matches the pattern
+func (w WildcardMask) Match(addr netip.Addr) bool {
+	if !w.IsValid() || !addr.IsValid() || addr.Is4() != w.Addr.Is4() {
+		return false
+	}
+
+	care := addrToUint128(w.Wildcard).not()
+	return addrToUint128(addr).and(care) == addrToUint128(w.Addr).and(care)
+}
+
+// Check if the address and wildcard are valid addresses of the same family
+func (w WildcardMask) IsValid() bool {
+	return w.Addr.IsValid() && w.Wildcard.IsValid() && w.Addr.Is4() == w.Wildcard.Is4()
+}
+
+// Check if the wildcard is the inverse of a subnet mask, so the pattern is also a prefix
+func (w WildcardMask) IsContiguous() bool {
+	if !w.IsValid() {
+		return false
+	}
+
+	return nonContiguousBit(invertAddr(w.Wildcard)) < 0
+}
+
+// Get the subnet that matches the same addresses, only possible for a contiguous wildcard
+func (w WildcardMask) Subnet() (Subnet, error) {
+	if !w.IsValid() {
+		return Subnet{}, ErrInvalidWildcard
+	}
+
+	return w.subnet(w.Wildcard.String())
+}
+
+// Convert to a subnet, reporting errors against the wildcard as it was written
+func (w WildcardMask) subnet(wildcardStr string) (Subnet, error) {
+	mask := invertAddr(w.Wildcard)
+	if bit := nonContiguousBit(mask); bit >= 0 {
+		return Subnet{}, newParseError(wildcardStr, locateBit(wildcardStr, bit), ErrInvalidWildcard, ErrNonContiguousMask)
+	}
+
+	bits, err := maskToBits(mask)
+	if err != nil {
+		return Subnet{}, err
+	}
+
+	return NewSubnet(netip.PrefixFrom(w.Addr, bits)), nil
+}
+
+// Count the number of addresses that match the pattern
+func (w WildcardMask) Count() *big.Int {
+	if !w.IsValid() {
+		return new(big.Int)
+	}
+
+	wildcard := addrToUint128(w.Wildcard)
+	ones := 0
+	for i := 0; i < w.Wildcard.BitLen(); i++ {
+		ones += int(wildcard.bit(i, w.Wildcard.BitLen()))
+	}
+	return new(big.Int).Lsh(big.NewInt(1), uint(ones))
+}
+
+// Format the pattern in the <ip-address> <wildcard-mask> format
+func (w WildcardMask) String() string {
+	if !w.IsValid() {
+		return "invalid WildcardMask"
+	}
+	return w.Addr.String() + " " + w.Wildcard.String()
+}
@@ -0,0 +1,98 @@
+package netmath
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+)
+
+func TestWildcard(t *testing.T) {
+	wildcardTests := []struct {
+		snet string
+		want string
+	}{
+		{snet: "192.168.20.15/23", want: "0.0.1.255"},
+		{snet: "10.0.0.0/8", want: "0.255.255.255"},
+		{snet: "0.0.0.0/0", want: "255.255.255.255"},
+		{snet: "10.0.0.1/32", want: "0.0.0.0"},
+		{snet: "2001:db8::/64", want: "::ffff:ffff:ffff:ffff"},
+	}
+
+	for _, test := range wildcardTests {
+		s, _ := ParseCIDR(test.snet)
+		w, err := s.Wildcard()
+		if err != nil || w.String() != test.want {
+			t.Error("Error getting .Wildcard() for", test.snet, "Expected:", test.want, "Got:", w, "With Error:", err)
+		}
+	}
+}
+
+func TestParseWildcard(t *testing.T) {
+	parseTests := []struct {
+		addr     string
+		wildcard string
+		want     string
+		err      error
+	}{
+		{addr: "10.1.1.0", wildcard: "0.0.0.255", want: "10.1.1.0/24"},
+		{addr: "172.16.0.0", wildcard: "0.15.255.255", want: "172.16.0.0/12"},
+		{addr: "10.1.1.1", wildcard: "0.0.0.0", want: "10.1.1.1/32"},
+		{addr: "0.0.0.0", wildcard: "255.255.255.255", want: "0.0.0.0/0"},
+		{addr: "2001:db8::", wildcard: "::ffff:ffff:ffff:ffff", want: "2001:db8::/64"},
+		{addr: "10.1.1.0", wildcard: "0.0.255.0", err: ErrNonContiguousMask},
+		{addr: "10.1.1.0", wildcard: "255.0.0.0", err: ErrNonContiguousMask},
+		{addr: "10.1.1.0", wildcard: "0.0.0.256", err: ErrOctetOutOfRange},
+		{addr: "10.1.1.0", wildcard: "::ff", err: ErrFamilyMismatch},
+		{addr: "10.1.1", wildcard: "0.0.0.255", err: ErrInvalidAddr},
+		{addr: "fe80::1%eth0", wildcard: "::ffff", err: ErrInvalidAddr},
+	}
+
+	for _, test := range parseTests {
+		s, err := ParseWildcard(test.addr, test.wildcard)
+		if err != nil || test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Error("Error parsing", test.addr, "and", test.wildcard, "Expected:", test.err, "With Error:", err)
+			}
+		} else if s.String() != test.want {
+			t.Error("Error parsing", test.addr, "and", test.wildcard, "Expected:", test.want, "Got:", s.String())
+		}
+	}
+}
+
+func TestWildcardMatch(t *testing.T) {
+	matchTests := []struct {
+		addr     string
+		wildcard string
+		match    string
+		want     bool
+	}{
+		{addr: "10.0.0.1", wildcard: "0.0.255.0", match: "10.0.77.1", want: true},
+		{addr: "10.0.0.1", wildcard: "0.0.255.0", match: "10.0.77.2", want: false},
+		{addr: "10.0.0.1", wildcard: "0.0.255.0", match: "10.1.0.1", want: false},
+		{addr: "192.168.0.0", wildcard: "0.0.0.254", match: "192.168.0.100", want: true}, // Even addresses only
+		{addr: "192.168.0.0", wildcard: "0.0.0.254", match: "192.168.0.101", want: false},
+		{addr: "10.1.1.0", wildcard: "0.0.0.255", match: "10.1.1.200", want: true},
+		{addr: "10.1.1.0", wildcard: "0.0.0.255", match: "::ffff:10.1.1.200", want: false},
+		{addr: "2001:db8::1", wildcard: "0:0:ffff::", match: "2001:db8:abcd::1", want: true},
+	}
+
+	for _, test := range matchTests {
+		w, err := ParseWildcardMask(test.addr, test.wildcard)
+		if err != nil {
+			t.Error("Error parsing", test.addr, "and", test.wildcard, "Error:", err)
+			continue
+		}
+		if got := w.Match(netip.MustParseAddr(test.match)); got != test.want {
+			t.Error("Error getting .Match() for", w, "with", test.match, "Expected:", test.want, "Got:", got)
+		}
+	}
+
+	w, _ := ParseWildcardMask("192.168.0.0", "0.0.0.254")
+	if w.IsContiguous() || w.Count().String() != "128" {
+		t.Error("Error inspecting", w, "Expected non-contiguous with 128 matches Got:", w.IsContiguous(), w.Count())
+	}
+	w, _ = ParseWildcardMask("192.168.0.0", "0.0.3.255")
+	if !w.IsContiguous() || w.Count().String() != "1024" {
+		t.Error("Error inspecting", w, "Expected contiguous with 1024 matches Got:", w.IsContiguous(), w.Count())
+	}
+}